@@ -0,0 +1,69 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"go/ast"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionSegment matches the last segment of the versioned packages, eg: v1 or v2beta1
+var versionSegment = regexp.MustCompile(`^v\d+(?:(?:alpha|beta)\d*)?$`)
+
+// VersionedPackagesShouldBeAliasedAs checks the imports of the versioned packages of current module, see
+// ArchPackage.VersionedPackagesShouldBeAliasedAs
+func VersionedPackagesShouldBeAliasedAs(pattern string) error {
+	return AllPackages().VersionedPackagesShouldBeAliasedAs(pattern)
+}
+
+// VersionedPackagesShouldBeAliasedAs checks the packages import the versioned packages of the module, the packages
+// whose paths end with a version, eg: .../api/v1, by the aliases matching the pattern, so the files do not refer to
+// the ambiguous identifiers, eg: v1. the pattern is a regular expression matching the whole alias, {parent} and
+// {version} in the pattern are replaced by the parent folder and the version of the imported package, eg:
+// "{parent}{version}" requires apiv1 for .../api/v1
+func (archPkg ArchPackage) VersionedPackagesShouldBeAliasedAs(pattern string) error {
+	if len(archPkg) == 0 {
+		return nil
+	}
+	arch := archPkg.architecture()
+	var result Findings
+	for _, pkg := range archPkg {
+		for _, file := range pkg.Raw().Syntax {
+			for _, spec := range file.Imports {
+				imported, _ := strconv.Unquote(spec.Path.Value)
+				version := path.Base(imported)
+				if !strings.HasPrefix(imported, arch.Module()+"/") || !versionSegment.MatchString(version) {
+					continue
+				}
+				alias := aliasOf(arch, spec, imported)
+				if alias == "_" || alias == "." {
+					continue
+				}
+				expected := strings.NewReplacer("{parent}", regexp.QuoteMeta(path.Base(path.Dir(imported))), "{version}", regexp.QuoteMeta(version)).Replace(pattern)
+				re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", expected))
+				if err != nil {
+					return err
+				}
+				if !re.MatchString(alias) {
+					position := pkg.Raw().Fset.Position(spec.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf(localize("%s imports %s as %s"), position, imported, alias)))
+				}
+			}
+		}
+	}
+	return lo.If(len(result) > 0, fmt.Errorf(localize("versioned packages are not aliased as %s: %w"), pattern, result.sorted())).Else(nil)
+}
+
+// aliasOf returns the name the import declares, the alias or the name of the imported package
+func aliasOf(arch Architecture, spec *ast.ImportSpec, imported string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	if pkg := arch.artifact.Package(imported); pkg != nil {
+		return pkg.Name()
+	}
+	return path.Base(imported)
+}
@@ -0,0 +1,27 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestArchPackage_VersionedPackagesShouldBeAliasedAs(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	err = pkgs.VersionedPackagesShouldBeAliasedAs("{parent}{version}")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	expected := []string{"app_controller.go:8:2 imports github.com/kcmvp/archunit/internal/sample/service/ext/v1 as v1",
+		"cross.go:3:8 imports github.com/kcmvp/archunit/internal/sample/service/ext/v1 as v1"}
+	assert.Len(t, violations, len(expected))
+	for _, suffix := range expected {
+		assert.True(t, lo.SomeBy(violations, func(v string) bool {
+			return strings.HasSuffix(v, suffix)
+		}), suffix)
+	}
+	assert.NoError(t, pkgs.VersionedPackagesShouldBeAliasedAs("{version}|api{version}"))
+	assert.Error(t, pkgs.VersionedPackagesShouldBeAliasedAs("("))
+	assert.NoError(t, ArchPackage{}.VersionedPackagesShouldBeAliasedAs("{parent}{version}"))
+}
@@ -0,0 +1,127 @@
+// Package analyzer runs the archunit rules as a go/analysis analyzer, so the rules are checked by go vet or
+// golangci-lint along with the other linters, eg:
+//
+//	func main() {
+//		singlechecker.Main(analyzer.New(rules...))
+//	}
+package analyzer
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// New returns the analyzer reporting the violations of the rules as the diagnostics of the analyzed packages. the
+// rules are validated once per module in a process, a violation is reported in the package of its source position,
+// or the first package it mentions when it has no position
+func New(rules ...archunit.Rule) *analysis.Analyzer {
+	checker := &checker{rules: rules, results: map[string]validation{}}
+	return &analysis.Analyzer{
+		Name: "archunit",
+		Doc:  "checks the architecture rules of archunit",
+		Run:  checker.run,
+	}
+}
+
+// validation is the violations of the rules on a module, packages matches the packages of the module the violations
+// mention
+type validation struct {
+	packages   *regexp.Regexp
+	violations []archunit.Violation
+	err        error
+}
+
+type checker struct {
+	rules   []archunit.Rule
+	mu      sync.Mutex
+	results map[string]validation
+}
+
+// validate returns the violations of the rules on the module in the directory, the module is validated only once
+func (checker *checker) validate(root string) validation {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if result, ok := checker.results[root]; ok {
+		return result
+	}
+	result := validation{}
+	arch, err := archunit.Load(root)
+	if err != nil {
+		result.err = err
+	} else {
+		result.packages = regexp.MustCompile(regexp.QuoteMeta(arch.Module()) + `(?:/[\w.\-]+)*`)
+		reporter := &collector{}
+		_ = arch.ValidateWithReport(reporter, checker.rules...)
+		result.violations = reporter.violations
+	}
+	checker.results[root] = result
+	return result
+}
+
+func (checker *checker) run(pass *analysis.Pass) (any, error) {
+	if len(pass.Files) == 0 {
+		return nil, nil
+	}
+	root, ok := moduleRoot(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+	if !ok {
+		return nil, nil
+	}
+	result := checker.validate(root)
+	if result.err != nil {
+		return nil, fmt.Errorf("archunit: %w", result.err)
+	}
+	files := lo.SliceToMap(pass.Files, func(file *ast.File) (string, *token.File) {
+		tf := pass.Fset.File(file.Pos())
+		return tf.Name(), tf
+	})
+	for _, violation := range result.violations {
+		var pos token.Pos
+		if tf, ok := files[violation.File]; ok && violation.Line > 0 && violation.Line <= tf.LineCount() {
+			pos = tf.LineStart(violation.Line) + token.Pos(max(violation.Column-1, 0))
+		} else if violation.File == "" && result.packages.FindString(violation.Message) == pass.Pkg.Path() {
+			pos = pass.Files[0].Package
+		} else {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: violation.Category,
+			Message:  fmt.Sprintf("%s: %s", violation.RuleID, violation.Message),
+		})
+	}
+	return nil, nil
+}
+
+// collector collects the violations of the failed rules
+type collector struct {
+	violations []archunit.Violation
+}
+
+func (collector *collector) Report(_ archunit.Architecture, results []archunit.RuleResult) error {
+	lo.ForEach(results, func(result archunit.RuleResult, _ int) {
+		collector.violations = append(collector.violations, result.Violations...)
+	})
+	return nil
+}
+
+// moduleRoot returns the closest directory of the go.mod from the directory up
+func moduleRoot(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
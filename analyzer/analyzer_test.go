@@ -0,0 +1,70 @@
+package analyzer
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"path/filepath"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	service, _ := filepath.Abs("../testdata/cycle/service/service.go")
+	rules := []archunit.Rule{
+		archunit.NewRule("cycles", func(pkgs archunit.ArchPackage) error {
+			return pkgs.ShouldBeFreeOfCycles()
+		}, "model", "service").WithCategory("package"),
+		archunit.NewRule("positioned", func(_ archunit.ArchPackage) error {
+			return fmt.Errorf("forbidden: %w", archunit.Findings{{Message: fmt.Sprintf("%s:6:2", service), File: service, Line: 6, Column: 2}})
+		}),
+	}
+	analyzer := New(rules...)
+	assert.Equal(t, "archunit", analyzer.Name)
+	tests := []struct {
+		pkg         string
+		file        string
+		diagnostics []string
+	}{
+		{
+			pkg:  "example.com/cycle/model",
+			file: "../testdata/cycle/model/model.go",
+			diagnostics: []string{
+				"1:1 package cycles: example.com/cycle/model -> example.com/cycle/service -> example.com/cycle/model",
+			},
+		},
+		{
+			pkg:  "example.com/cycle/service",
+			file: "../testdata/cycle/service/service.go",
+			diagnostics: []string{
+				fmt.Sprintf("6:2  positioned: %s:6:2", service),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.pkg, func(t *testing.T) {
+			fset := token.NewFileSet()
+			path, _ := filepath.Abs(test.file)
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			assert.NoError(t, err)
+			var diagnostics []string
+			pass := &analysis.Pass{
+				Analyzer: analyzer,
+				Fset:     fset,
+				Files:    []*ast.File{file},
+				Pkg:      types.NewPackage(test.pkg, file.Name.Name),
+				Report: func(d analysis.Diagnostic) {
+					position := fset.Position(d.Pos)
+					diagnostics = append(diagnostics, fmt.Sprintf("%d:%d %s %s", position.Line, position.Column, d.Category, d.Message))
+				},
+			}
+			_, err = analyzer.Run(pass)
+			assert.NoError(t, err)
+			assert.Equal(t, test.diagnostics, diagnostics)
+		})
+	}
+}
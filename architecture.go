@@ -0,0 +1,236 @@
+package archunit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Architecture is the analyzed project, rules are validated against its packages
+type Architecture struct {
+	artifact *internal.Artifact
+	report   reportOptions
+	cache    *ResultCache
+}
+
+// Rule is a named check on the packages selected by the paths
+type Rule struct {
+	name         string
+	paths        []string
+	check        func(arch Architecture, pkgs ArchPackage) error
+	wholeProgram bool
+	category     string
+	rationale    string
+	message      func(v ViolationContext) string
+}
+
+// RuleCoverage is the names of the rules whose selections include the package, keyed by the package
+type RuleCoverage map[string][]string
+
+// Project returns the architecture of current module
+func Project() Architecture {
+	return Architecture{artifact: internal.Arch()}
+}
+
+// Load returns the architecture of the go module in the directory
+func Load(dir string) (Architecture, error) {
+	artifact, err := internal.Load(dir)
+	if err != nil {
+		return Architecture{}, fmt.Errorf(localize("can not load %s: %w"), dir, err)
+	}
+	return Architecture{artifact: artifact}, nil
+}
+
+// PackageIndex is the pre-exported package index of a module, the architecture is loaded from the index by LoadIndex
+// without running the go command, eg: in WASM
+type PackageIndex = internal.Index
+
+// LoadIndex returns the architecture of the package index, the packages out of the index, eg: the standard library,
+// are imported from the export data embedded by Architecture.Index, the references to the packages without the
+// export data are left untyped and reported as the type errors of the packages
+func LoadIndex(index PackageIndex) (Architecture, error) {
+	artifact, err := internal.LoadIndex(index)
+	if err != nil {
+		return Architecture{}, fmt.Errorf(localize("can not load index of %s: %w"), index.Module, err)
+	}
+	return Architecture{artifact: artifact}, nil
+}
+
+// Index exports the package index of the architecture, the sources are embedded when sources is true so the index
+// can be loaded on the machines without the source files
+func (arch Architecture) Index(sources bool) (PackageIndex, error) {
+	return arch.artifact.Index(sources)
+}
+
+// NewRule creates a rule which checks the packages of the paths, eg:
+// NewRule("service should not refer controller", func(pkgs ArchPackage) error {
+// return pkgs.ShouldNotReferPkgPaths("sample/controller")
+// }, "sample/service/...")
+func NewRule(name string, check func(pkgs ArchPackage) error, paths ...string) Rule {
+	return Rule{name: name, paths: paths, check: func(_ Architecture, pkgs ArchPackage) error {
+		return check(pkgs)
+	}}
+}
+
+func (rule Rule) Name() string {
+	return rule.name
+}
+
+func (rule Rule) Paths() []string {
+	return rule.paths
+}
+
+// WithCategory returns the rule of the category, the categories group the rules in the reports, eg: layer or naming
+func (rule Rule) WithCategory(category string) Rule {
+	rule.category = category
+	return rule
+}
+
+func (rule Rule) Category() string {
+	return rule.category
+}
+
+// Because returns the rule with the rationale, the rationale is documented along with the rule by Describe
+func (rule Rule) Because(rationale string) Rule {
+	rule.rationale = rationale
+	return rule
+}
+
+func (rule Rule) Rationale() string {
+	return rule.rationale
+}
+
+// WithMessage returns the rule reporting its violations in the messages of the format, eg: with the remediation links
+// or the owner teams. the summaries of the trimmed violations, eg: "... and 3 more", are not formatted
+func (rule Rule) WithMessage(format func(v ViolationContext) string) Rule {
+	rule.message = format
+	return rule
+}
+
+// RootDir returns the root directory of the module
+func (arch Architecture) RootDir() string {
+	return arch.artifact.RootDir()
+}
+
+// Module returns the path of the module
+func (arch Architecture) Module() string {
+	return arch.artifact.Module()
+}
+
+// Packages returns the packages of the architecture which match the paths
+func (arch Architecture) Packages(paths ...string) (ArchPackage, error) {
+	patterns, err := ScopePattern(paths...)
+	return lo.Filter(arch.artifact.Packages(), func(pkg *internal.Package, _ int) bool {
+		return lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(pkg.ID())
+		})
+	}), err
+}
+
+// Validate checks all the rules and returns the joined errors of the failed rules, one line per rule.
+// the report is trimmed by the options of the architecture, see With
+func (arch Architecture) Validate(rules ...Rule) error {
+	return arch.join(arch.results(rules...))
+}
+
+// ValidateWithReport checks all the rules the same as Validate and writes the results of all the rules, passed or
+// failed, by the reporter, eg: the SARIF reporter of package sarif
+func (arch Architecture) ValidateWithReport(reporter Reporter, rules ...Rule) error {
+	results := arch.results(rules...)
+	if err := reporter.Report(arch, results); err != nil {
+		return fmt.Errorf(localize("can not report: %w"), err)
+	}
+	return arch.join(results)
+}
+
+// results checks the rules one by one, the errors of the failed rules are trimmed by the report options while the
+// violations of the results are kept in full
+func (arch Architecture) results(rules ...Rule) []RuleResult {
+	return lo.Map(rules, func(rule Rule, _ int) RuleResult {
+		result := RuleResult{Rule: rule.name, Category: rule.category}
+		pkgs, err := arch.Packages(rule.paths...)
+		if err == nil {
+			result.Cached, err = arch.cache.check(arch, rule, pkgs)
+		}
+		if err != nil {
+			violations, wrapped := found(err)
+			violations = lo.Map(violations, func(violation Violation, _ int) Violation {
+				return arch.violation(rule, violation)
+			})
+			// the report options trim the error only, the reporters get all the violations
+			trimmed := append([]Violation{}, violations...)
+			if wrapped {
+				trimmed = arch.trim(trimmed)
+			}
+			if rule.message != nil {
+				rule.formatMessages(violations)
+				err = rule.format(err, trimmed, wrapped)
+			} else if wrapped {
+				err = rewrap(err, trimmed)
+			}
+			result.Violations = violations
+			result.Err = fmt.Errorf("%s: %w", rule.name, err)
+		}
+		return result
+	})
+}
+
+// join returns the joined errors of the failed rules, at most the max lines of the report options, or the digest of
+// them when the summary is reported, see Summary
+func (arch Architecture) join(results []RuleResult) error {
+	if arch.report.summary != nil {
+		return arch.summarize(results)
+	}
+	err := errors.Join(lo.Map(results, func(result RuleResult, _ int) error {
+		return result.Err
+	})...)
+	if n := arch.report.maxLines; err != nil && n > 0 {
+		if lines := strings.Split(err.Error(), "\n"); len(lines) > n {
+			return errors.New(strings.Join(append(lines[:n:n], fmt.Sprintf(localize("... and %d more"), len(lines)-n)), "\n"))
+		}
+	}
+	return err
+}
+
+// CoverageReport computes the rules applied on each package of the architecture. packages without any rule are
+// the blind spots of the architecture tests, see RuleCoverage.Ungoverned
+func (arch Architecture) CoverageReport(rules ...Rule) (RuleCoverage, error) {
+	coverage := RuleCoverage{}
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		coverage[pkg.ID()] = []string{}
+	})
+	for _, rule := range rules {
+		pkgs, err := arch.Packages(rule.paths...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rule.name, err)
+		}
+		lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+			coverage[pkg.ID()] = append(coverage[pkg.ID()], rule.name)
+		})
+	}
+	return coverage, nil
+}
+
+// Ungoverned returns the packages no rule applies on
+func (coverage RuleCoverage) Ungoverned() []string {
+	pkgs := lo.Keys(lo.PickBy(coverage, func(_ string, rules []string) bool {
+		return len(rules) == 0
+	}))
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// String lists the number of rules applied on each package, the ungoverned packages are highlighted
+func (coverage RuleCoverage) String() string {
+	pkgs := lo.Keys(coverage)
+	sort.Strings(pkgs)
+	return strings.Join(lo.Map(pkgs, func(pkg string, _ int) string {
+		rules := coverage[pkg]
+		return lo.If(len(rules) == 0, fmt.Sprintf("%s: 0 rules (ungoverned)", pkg)).
+			Else(fmt.Sprintf("%s: %d rules %v", pkg, len(rules), rules))
+	}), "\n")
+}
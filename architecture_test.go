@@ -0,0 +1,367 @@
+package archunit
+
+import (
+	"errors"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchitecture_Validate(t *testing.T) {
+	arch := Project()
+	rule := NewRule("service should not refer controller", func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotReferPkgPaths("sample/controller")
+	}, "sample/service/...")
+	assert.Equal(t, "service should not refer controller", rule.Name())
+	assert.Equal(t, []string{"sample/service/..."}, rule.Paths())
+	assert.NoError(t, arch.Validate(rule))
+	broken := NewRule("controller should not refer service", func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotReferPkgPaths("sample/service")
+	}, "sample/controller")
+	err := arch.Validate(rule, broken)
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "controller should not refer service: "))
+	assert.Error(t, arch.Validate(NewRule("invalid", func(pkgs ArchPackage) error {
+		return nil
+	}, "sample/[a")))
+}
+
+func TestArchitecture_CoverageReport(t *testing.T) {
+	arch := Project()
+	noop := func(pkgs ArchPackage) error {
+		return nil
+	}
+	coverage, err := arch.CoverageReport(
+		NewRule("service", noop, "sample/service/..."),
+		NewRule("sample", noop, "sample/..."),
+	)
+	assert.NoError(t, err)
+	assert.Len(t, coverage, len(AllPackages()))
+	assert.Equal(t, []string{"service", "sample"}, coverage["github.com/kcmvp/archunit/internal/sample/service/ext"])
+	assert.Equal(t, []string{"sample"}, coverage["github.com/kcmvp/archunit/internal/sample/model"])
+	assert.Equal(t, []string{"github.com/kcmvp/archunit", "github.com/kcmvp/archunit/analyzer", "github.com/kcmvp/archunit/archtest", "github.com/kcmvp/archunit/config", "github.com/kcmvp/archunit/diagnostics", "github.com/kcmvp/archunit/internal", "github.com/kcmvp/archunit/junit", "github.com/kcmvp/archunit/plugin", "github.com/kcmvp/archunit/promote", "github.com/kcmvp/archunit/sarif"}, coverage.Ungoverned())
+	assert.Contains(t, coverage.String(), "github.com/kcmvp/archunit/internal: 0 rules (ungoverned)")
+	assert.Contains(t, coverage.String(), "github.com/kcmvp/archunit/internal/sample/model: 1 rules [sample]")
+	_, err = arch.CoverageReport(NewRule("invalid", noop, "sample/[a"))
+	assert.Error(t, err)
+}
+
+func TestLoadIndex(t *testing.T) {
+	arch, err := Load("testdata/cycle")
+	assert.NoError(t, err)
+	index, err := arch.Index(true)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/cycle", index.Module)
+	assert.Len(t, index.Packages, 3)
+	assert.Equal(t, []string{"example.com/cycle/model"}, index.Packages[2].Imports)
+	indexed, err := LoadIndex(index)
+	assert.NoError(t, err)
+	pkgs, err := indexed.Packages("cycle/model", "cycle/service")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"example.com/cycle/model", "example.com/cycle/service"}, pkgs.ID())
+	assert.Error(t, pkgs.ShouldBeFreeOfCycles())
+	assert.Equal(t, []string{"example.com/cycle/service.Find"}, lo.Map(pkgs.Functions(), func(f internal.Function, _ int) string {
+		return f.FullName()
+	}))
+	// the rules are checked on the indexed packages rather than the packages of the project
+	returns := NewRule("returns", func(pkgs ArchPackage) error {
+		return pkgs.Functions().ShouldHaveAtMostReturns(0)
+	}, "cycle/service")
+	assert.EqualError(t, indexed.Validate(returns), "returns: functions have more than 0 results: [example.com/cycle/service.Find (1)]")
+	index.Packages[0].Sources = map[string]string{index.Packages[0].GoFiles[0]: "package"}
+	_, err = LoadIndex(index)
+	assert.Error(t, err)
+}
+
+// TestLoad_Bound checks the rules and the selections are bound to the loaded architecture rather than the project
+func TestLoad_Bound(t *testing.T) {
+	arch, err := Load("testdata/bound")
+	assert.NoError(t, err)
+	api, err := arch.Packages("bound/api")
+	assert.NoError(t, err)
+	functions := lo.SliceToMap(api.Functions(), func(f Function) (string, Function) {
+		return f.Name(), f
+	})
+	relative := func(messages []string) []string {
+		return lo.Map(messages, func(message string, _ int) string {
+			return strings.ReplaceAll(message, arch.RootDir()+string(filepath.Separator), "")
+		})
+	}
+	// violations returns the violations of the error, or the error itself when it does not wrap violations
+	violations := func(err error) []string {
+		var result Violations
+		if err == nil {
+			return nil
+		} else if !errors.As(err, &result) {
+			return relative([]string{err.Error()})
+		}
+		return relative(result)
+	}
+	names := func(functions Functions) []string {
+		return lo.Map(functions, func(f Function, _ int) string {
+			return f.FullName()
+		})
+	}
+	tests := []struct {
+		name string
+		got  func() []string
+		want []string
+	}{
+		{
+			name: "CallersOf",
+			got: func() []string {
+				return relative(lo.Map(append(arch.CallersOf("api.Publish"), arch.CallersOf("internal/sample/flags.Enabled")...), func(site internal.CallSite, _ int) string {
+					return callSite(site)
+				}))
+			},
+			want: []string{
+				"api/api.go:68:2 example.com/bound/api.Notify -> example.com/bound/api.Publish",
+			},
+		},
+		{
+			name: "ShouldOnlyBeCalledBy",
+			got: func() []string {
+				publish := api.Functions().Matching(FunctionNameMatches("api.Publish"))
+				return append(violations(publish.ShouldOnlyBeCalledBy(FunctionNameMatches("api.Notify"))),
+					violations(publish.ShouldOnlyBeCalledBy(FunctionNameMatches("api.Open")))...)
+			},
+			want: []string{
+				"api/api.go:68:2 example.com/bound/api.Notify calls example.com/bound/api.Publish",
+			},
+		},
+		{
+			name: "Callers and Callees",
+			got: func() []string {
+				return append(names(api.Functions().Matching(FunctionNameMatches("api.Publish")).Callers()),
+					names(api.Functions().Matching(FunctionNameMatches("api.Tested")).Callees())...)
+			},
+			want: []string{"example.com/bound/api.Notify", "example.com/bound/flags.Enabled"},
+		},
+		{
+			name: "ConstantsOfType",
+			got: func() []string {
+				constants, err := arch.ConstantsOfType("example.com/bound/api.Color")
+				assert.NoError(t, err)
+				return append(lo.Map(constants, func(c Constant, _ int) string {
+					return c.Name()
+				}), violations(constants.SwitchesShouldBeExhaustive())...)
+			},
+			want: []string{
+				"Blue",
+				"Green",
+				"Red",
+				"api/color.go:14:2 misses [Blue]",
+			},
+		},
+		{
+			name: "Constants",
+			got: func() []string {
+				return lo.Map(arch.Constants(ConstantNameMatches(HavePrefix, "B")), func(c Constant, _ int) string {
+					return c.Name()
+				})
+			},
+			want: []string{"Blue"},
+		},
+		{
+			name: "ConstructorGraphShouldBeAcyclic",
+			got: func() []string {
+				return violations(arch.ConstructorGraphShouldBeAcyclic())
+			},
+			want: []string{
+				"example.com/bound/service.NewOrderService -> example.com/bound/service.NewUserService -> example.com/bound/service.NewOrderService",
+			},
+		},
+		{
+			name: "Custom",
+			got: func() []string {
+				RegisterExtractor("bound handlers", handlers)
+				objects, err := arch.Custom("bound handlers")
+				assert.NoError(t, err)
+				return lo.Map(objects, func(object CustomObject, _ int) string {
+					return object.Name
+				})
+			},
+			want: []string{},
+		},
+		{
+			name: "ShouldNotUseLanguageFeaturesBeyond",
+			got: func() []string {
+				return append(violations(arch.ShouldNotUseLanguageFeaturesBeyond("1.22")),
+					violations(arch.ShouldNotUseLanguageFeaturesBeyond("1.21"))...)
+			},
+			want: []string{"service/service.go:21:2 range over integer requires go1.22"},
+		},
+		{
+			name: "FeatureFlagsOf",
+			got: func() []string {
+				flags, err := arch.FeatureFlagsOf("example.com/bound/flags")
+				assert.NoError(t, err)
+				return append(append(relative(flags.CallSites()), violations(flags.ShouldBeCheckedOnlyIn("api"))...),
+					violations(flags.ShouldBeCheckedOnlyIn("a"))...)
+			},
+			want: []string{
+				"api/api.go:48:9 example.com/bound/api.Tested -> example.com/bound/flags.Enabled",
+				"api/api.go:48:9 example.com/bound/api.Tested -> example.com/bound/flags.Enabled",
+			},
+		},
+		{
+			name: "ExportedFunctionsShouldNotPanic",
+			got: func() []string {
+				return violations(ExportedFunctionsShouldNotPanic(api.Functions()))
+			},
+			want: []string{"example.com/bound/api.Parse at api/api.go:31:3"},
+		},
+		{
+			name: "ExportedFunctionsShouldHaveTests",
+			got: func() []string {
+				return append(violations(ExportedFunctionsShouldHaveTests(api.Functions())),
+					violations(ExportedFunctionsShouldHaveTests(api.Types().Methods()))...)
+			},
+			want: []string{
+				"example.com/bound/api.Must",
+				"example.com/bound/api.Name",
+				"example.com/bound/api.Notify",
+				"example.com/bound/api.Open",
+				"example.com/bound/api.Parse",
+				"example.com/bound/api.Publish",
+				"example.com/bound/api.Stable",
+				"(example.com/bound/api.FileStore).Read",
+				"(example.com/bound/api.Store).Get",
+			},
+		},
+		{
+			name: "BenchmarksAndFuzzTestsShouldResideIn",
+			got: func() []string {
+				return append(violations(arch.BenchmarksAndFuzzTestsShouldResideIn("absent")),
+					violations(arch.ExamplesShouldReferenceExistingIdentifiers())...)
+			},
+		},
+		{
+			name: "ignored",
+			got: func() []string {
+				return lo.FilterMap([]string{"Must", "Parse"}, func(name string, _ int) (string, bool) {
+					return name, arch.ignored("ExportedFunctionsShouldNotPanic", functions[name].Raw()) &&
+						!Project().ignored("ExportedFunctionsShouldNotPanic", functions[name].Raw())
+				})
+			},
+			want: []string{"Must"},
+		},
+		{
+			name: "PackagesWithInitShouldNotDependOnEachOther",
+			got: func() []string {
+				return violations(arch.PackagesWithInitShouldNotDependOnEachOther())
+			},
+			want: []string{"example.com/bound/a -> example.com/bound/b"},
+		},
+		{
+			name: "Layer",
+			got: func() []string {
+				layer, err := arch.Layer("api")
+				assert.NoError(t, err)
+				return append(append(layer.packages(), violations(layer.ShouldNotReferPackages("a"))...),
+					violations(layer.ShouldNotReferPackages("flags"))...)
+			},
+			want: []string{
+				"example.com/bound/api",
+				"[example.com/bound/api] refers example.com/bound/flags",
+			},
+		},
+		{
+			name: "SourceNameShould",
+			got: func() []string {
+				return append(violations(arch.SourceNameShould(BeLowerCase)), violations(arch.ConstantsShouldBeDefinedInOneFileByPackage())...)
+			},
+		},
+		{
+			name: "LayersShouldMatchManifest",
+			got: func() []string {
+				return violations(arch.LayersShouldMatchManifest("layers.yaml"))
+			},
+			want: []string{"layer flags [flags] is not declared in the code"},
+		},
+		{
+			name: "MigrationsShouldBeSequential",
+			got: func() []string {
+				return append(violations(arch.MigrationsShouldBeSequential("migrations", `^(\d+)_.*\.up\.sql$`)),
+					violations(arch.MigrationsShouldNotBeModified("migrations.sum"))...)
+			},
+			want: []string{
+				"003_users.up.sql does not follow 001_init.up.sql",
+				"open migrations.sum: no such file or directory",
+			},
+		},
+		{
+			name: "OSSpecificCallsShouldBeLimitedTo",
+			got: func() []string {
+				return append(violations(arch.OSSpecificCallsShouldBeLimitedTo("api")), violations(arch.OSSpecificCallsShouldBeLimitedTo("flags"))...)
+			},
+			want: []string{"api/api.go:77:17 syscall.Getpid"},
+		},
+		{
+			name: "OpenedResourcesShouldBeClosed",
+			got: func() []string {
+				return violations(arch.OpenedResourcesShouldBeClosed())
+			},
+			want: []string{"os.Open at api/api.go:73:12"},
+		},
+		{
+			name: "StableAPIShouldNotDependOnExperimental",
+			got: func() []string {
+				return violations(arch.StableAPIShouldNotDependOnExperimental())
+			},
+			want: []string{"example.com/bound/api.Stable -> example.com/bound/api.Beta"},
+		},
+		{
+			name: "TestsShouldUseAssertionLibrary",
+			got: func() []string {
+				return append(violations(arch.TestsShouldUseAssertionLibrary("github.com/stretchr/testify")),
+					violations(arch.TestsShouldUseAssertionLibrary())...)
+			},
+			want: []string{
+				"api/api_test.go:5:2 github.com/stretchr/testify/assert",
+				"api/store_test.go:8:2 github.com/stretchr/testify/assert",
+			},
+		},
+		{
+			name: "TopicsOf",
+			got: func() []string {
+				return append(arch.TopicsOf(StringArg(0, "api.Publish")).Names(), arch.TopicsOf(StringArg(0, "internal/sample/flags.Enabled")).Names()...)
+			},
+			want: []string{"orders.created"},
+		},
+		{
+			name: "TypesIntendedToImplement",
+			got: func() []string {
+				return violations(arch.TypesIntendedToImplement("example.com/bound/api.Store", TypeNameMatches(HaveSuffix, "Store")))
+			},
+			want: []string{"example.com/bound/api.FileStore misses Get"},
+		},
+		{
+			name: "MethodsImplementing",
+			got: func() []string {
+				methods, err := arch.MethodsImplementing("example.com/bound/api.Store")
+				assert.NoError(t, err)
+				return names(methods)
+			},
+			want: []string{"(example.com/bound/api.MemStore).Get"},
+		},
+		{
+			name: "Implementers",
+			got: func() []string {
+				stores := api.Types().Matching(TypeNameMatches(HaveSuffix, "api.Store"))
+				return lo.Map(stores.Implementers(), func(typ Type, _ int) string {
+					return typ.Name()
+				})
+			},
+			want: []string{"example.com/bound/api.MemStore"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.got())
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package archtest provides the helpers to develop and test the architecture rules against small synthetic projects
+package archtest
+
+import (
+	"errors"
+	"github.com/kcmvp/archunit"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureModule is the module name of the fixtures without go.mod
+const fixtureModule = "fixture"
+
+// LoadFixture copies the fixture directory to a temporary module and returns its architecture. a go.mod of module
+// `fixture` is generated when the fixture does not have one, so the fixtures can be plain directories of go files
+// without being picked up by the project itself, eg: testdata/layered. the test fails when the fixture can not be loaded
+func LoadFixture(t testing.TB, dir string) archunit.Architecture {
+	t.Helper()
+	root := t.TempDir()
+	if err := copyDir(dir, root); err != nil {
+		t.Fatalf("failed to copy fixture %s: %v", dir, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "go.mod")); errors.Is(err, fs.ErrNotExist) {
+		if err = os.WriteFile(filepath.Join(root, "go.mod"), []byte("module "+fixtureModule+"\n\ngo 1.22\n"), 0o644); err != nil {
+			t.Fatalf("failed to create go.mod: %v", err)
+		}
+	}
+	arch, err := archunit.Load(root)
+	if err != nil {
+		t.Fatalf("failed to load fixture %s: %v", dir, err)
+	}
+	return arch
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -0,0 +1,48 @@
+package archtest
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLoadFixture(t *testing.T) {
+	arch := LoadFixture(t, "testdata/layered")
+	pkgs, err := arch.Packages("...")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"fixture/repository", "fixture/service"}, pkgs.ID())
+	assert.Equal(t, []string{"fixture/repository"}, pkgs.Imports())
+	assert.NoError(t, arch.Validate(archunit.NewRule("name", func(pkgs archunit.ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "...")))
+	arch = LoadFixture(t, "../promote/testdata/violation")
+	pkgs, _ = arch.Packages("...")
+	assert.ElementsMatch(t, []string{"example.com/violation/controller", "example.com/violation/service"}, pkgs.ID())
+}
+
+func TestLoadFixture_Parallel(t *testing.T) {
+	archs := make([]archunit.Architecture, 2)
+	var wg sync.WaitGroup
+	for i, dir := range []string{"testdata/layered", "../promote/testdata/violation"} {
+		wg.Add(1)
+		go func(i int, dir string) {
+			defer wg.Done()
+			archs[i] = LoadFixture(t, dir)
+		}(i, dir)
+	}
+	wg.Wait()
+	service, _ := archs[0].Packages("service")
+	assert.Equal(t, []string{"fixture/service"}, service.ID())
+	assert.NoError(t, service.ShouldOnlyReferPkgPaths("repository"))
+	assert.Error(t, service.ShouldNotReferPkgPaths("repository"))
+	assert.Len(t, service.Functions(), 1)
+	assert.True(t, strings.HasSuffix(service.Functions()[0].GoFile(), "service/service.go"))
+	service, _ = archs[1].Packages("service")
+	assert.Equal(t, []string{"example.com/violation/service"}, service.ID())
+	assert.Error(t, service.ShouldNotReferPkgPaths("controller"))
+	assert.NoError(t, service.ShouldNotReferPkgPaths("repository"))
+	project, _ := archunit.Packages("sample/service")
+	assert.NoError(t, project.ShouldNotReferPkgPaths("controller"))
+}
@@ -0,0 +1,5 @@
+package repository
+
+func FindUser(id string) string {
+	return id
+}
@@ -0,0 +1,7 @@
+package service
+
+import "fixture/repository"
+
+func GetUser(id string) string {
+	return repository.FindUser(id)
+}
@@ -0,0 +1,52 @@
+package archunit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"runtime"
+)
+
+// WholeProgram marks the rule needs all the packages of the module, eg: rules checking the referrers of the packages,
+// ValidateInBatches checks such rules once against the whole module instead of against every batch
+func (rule Rule) WholeProgram() Rule {
+	rule.wholeProgram = true
+	return rule
+}
+
+// ValidateInBatches validates the rules against the go module in the directory batch by batch for the very large
+// modules, the packages are loaded in batches of size in the dependency order and every batch is released before
+// the next one is loaded. the rules see only the packages of the batch, the rules marked with WholeProgram are
+// checked against the whole module after all the batches
+func ValidateInBatches(dir string, size int, rules ...Rule) error {
+	order, err := internal.PackageOrder(dir)
+	if err != nil {
+		return fmt.Errorf(localize("can not list packages of %s: %w"), dir, err)
+	}
+	global := lo.Filter(rules, func(rule Rule, _ int) bool {
+		return rule.wholeProgram
+	})
+	local := lo.Reject(rules, func(rule Rule, _ int) bool {
+		return rule.wholeProgram
+	})
+	var errs []error
+	if len(local) > 0 {
+		for _, batch := range lo.Chunk(order, max(size, 1)) {
+			artifact, err := internal.LoadPackages(dir, batch...)
+			if err != nil {
+				return fmt.Errorf(localize("can not load packages %v: %w"), batch, err)
+			}
+			errs = append(errs, Architecture{artifact: artifact}.Validate(local...))
+			runtime.GC()
+		}
+	}
+	if len(global) > 0 {
+		arch, err := Load(dir)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, arch.Validate(global...))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,36 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestValidateInBatches(t *testing.T) {
+	var batches [][]string
+	local := NewRule("batch", func(pkgs ArchPackage) error {
+		batches = append(batches, pkgs.ID())
+		return nil
+	}, "cycle/...")
+	var whole []string
+	global := NewRule("whole", func(pkgs ArchPackage) error {
+		whole = pkgs.ID()
+		return nil
+	}, "cycle/...").WholeProgram()
+	assert.NoError(t, ValidateInBatches("testdata/cycle", 2, local, global))
+	assert.Equal(t, [][]string{
+		{"example.com/cycle/model", "example.com/cycle/service"},
+		{"example.com/cycle/model/dto"},
+	}, lo.Map(batches, func(batch []string, _ int) []string {
+		sort.Strings(batch)
+		return batch
+	}))
+	assert.Len(t, whole, 3)
+	err := ValidateInBatches("testdata/cycle", 1, NewRule("name", func(pkgs ArchPackage) error {
+		return lo.If(len(pkgs) > 0, fmt.Errorf("%s", pkgs.ID()[0])).Else(nil)
+	}, "cycle/..."))
+	assert.EqualError(t, err, "name: example.com/cycle/model\nname: example.com/cycle/service\nname: example.com/cycle/model/dto")
+	assert.Error(t, ValidateInBatches("testdata/missing", 1, local))
+}
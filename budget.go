@@ -0,0 +1,59 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// PackagesShouldExportAtMost checks each package of the selection exports at most n package level symbols,
+// large public APIs are the sign of packages doing too much. the exported symbols are listed in the report
+func PackagesShouldExportAtMost(n int, pkgs ArchPackage) error {
+	var result []string
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		scope := pkg.Raw().Types.Scope()
+		exported := lo.Filter(scope.Names(), func(name string, _ int) bool {
+			return ast.IsExported(name)
+		})
+		if len(exported) > n {
+			result = append(result, fmt.Sprintf(localize("%s exports %d symbols %v"), pkg.ID(), len(exported), exported))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages export more than %d symbols: %w"), n, Violations(result))).Else(nil)
+}
+
+// PackagesShouldImportAtMost checks each package of the selection imports at most n packages of the project,
+// the imports out of the project are counted as well when external is true. the imports are listed in the report
+func PackagesShouldImportAtMost(n int, pkgs ArchPackage, external ...bool) error {
+	var result []string
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		module := pkg.Artifact().Module()
+		imports := lo.Filter(pkg.Imports(), func(path string, _ int) bool {
+			return (len(external) > 0 && external[0]) || path == module || strings.HasPrefix(path, module+"/")
+		})
+		sort.Strings(imports)
+		if len(imports) > n {
+			result = append(result, fmt.Sprintf(localize("%s imports %d packages %v"), pkg.ID(), len(imports), imports))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages import more than %d packages: %w"), n, Violations(result))).Else(nil)
+}
+
+// PackagesShouldBeReferredByAtMost checks each package of the selection is imported by at most n packages of the project,
+// so volatile packages, eg: experimental or deprecated ones, do not accumulate more dependents. the dependents are listed in the report
+func PackagesShouldBeReferredByAtMost(n int, pkgs ArchPackage) error {
+	var result []string
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		dependents := lo.FilterMap(pkg.Artifact().Packages(), func(dependent *internal.Package, _ int) (string, bool) {
+			return dependent.ID(), lo.Contains(dependent.Imports(), pkg.ID())
+		})
+		sort.Strings(dependents)
+		if len(dependents) > n {
+			result = append(result, fmt.Sprintf(localize("%s is referred by %d packages %v"), pkg.ID(), len(dependents), dependents))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages are referred by more than %d packages: %w"), n, Violations(result))).Else(nil)
+}
@@ -0,0 +1,48 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPackagesShouldExportAtMost(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	assert.NoError(t, PackagesShouldExportAtMost(7, pkgs))
+	err = PackagesShouldExportAtMost(6, pkgs)
+	assert.Error(t, err)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/repository exports 7 symbols [F1 F2 F3 FF Mast Slave UserRepository]",
+	}, violations)
+}
+
+func TestPackagesShouldImportAtMost(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	assert.NoError(t, PackagesShouldImportAtMost(3, pkgs))
+	err = PackagesShouldImportAtMost(4, pkgs, true)
+	assert.Error(t, err)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/controller imports 7 packages [context fmt github.com/kcmvp/archunit/internal/sample/flags github.com/kcmvp/archunit/internal/sample/service github.com/kcmvp/archunit/internal/sample/views net/http time]",
+	}, violations)
+	err = PackagesShouldImportAtMost(2, pkgs)
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 2)
+}
+
+func TestPackagesShouldBeReferredByAtMost(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	assert.NoError(t, PackagesShouldBeReferredByAtMost(3, pkgs))
+	err = PackagesShouldBeReferredByAtMost(2, pkgs)
+	assert.Error(t, err)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/repository is referred by 3 packages [github.com/kcmvp/archunit/internal/sample/controller/module1 github.com/kcmvp/archunit/internal/sample/service github.com/kcmvp/archunit/internal/sample/service/thirdparty]",
+	}, violations)
+}
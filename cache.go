@@ -0,0 +1,235 @@
+package archunit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ResultCache caches the results of the rules in a store, keyed by the rule name, the paths, the selected packages
+// and the content of their go and _test.go files, so the rules of unchanged packages are skipped on repeat runs. the
+// check of a rule can not be fingerprinted, rules should be renamed when their checks change, and rules checking the
+// packages out of their selection, eg: ConstructorGraphShouldBeAcyclic, should not be cached. the hits and misses
+// are reported by the summary and the SARIF reporter, see Architecture.CacheStats
+type ResultCache struct {
+	store  CacheStore
+	mu     sync.Mutex
+	hits   int
+	misses int
+}
+
+// CacheStats is the number of the rules skipped by the cache and the rules checked
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
+func (stats CacheStats) String() string {
+	return fmt.Sprintf("%d rules cached, %d rules checked", stats.Hits, stats.Misses)
+}
+
+// cachedResult is the result of a rule stored in the cache, violations are kept so the report can still be trimmed
+type cachedResult struct {
+	Error      string      `json:"error,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// CacheStore is the key value storage of the results of ResultCache, eg: a local directory or a remote cache shared
+// by the CI runners. Get returns an error wrapping fs.ErrNotExist for the absent keys
+type CacheStore interface {
+	Get(key string) ([]byte, error)
+	Put(key string, data []byte) error
+}
+
+// rootToken replaces the root of the architecture in the cached results, so the results are shared by the machines
+// checking out the project in different directories
+var rootToken = "${root}"
+
+// NewResultCache creates the cache storing the results in the directory, the directory is created on the first store
+func NewResultCache(dir string) *ResultCache {
+	return NewStoreCache(DirStore(dir))
+}
+
+// NewStoreCache creates the cache storing the results in the store, eg: NewStoreCache(HTTPStore(url)) shares the
+// results across the CI runners like a remote build cache
+func NewStoreCache(store CacheStore) *ResultCache {
+	return &ResultCache{store: store}
+}
+
+// WithCache returns the architecture skipping the rules whose results are cached
+func (arch Architecture) WithCache(cache *ResultCache) Architecture {
+	arch.cache = cache
+	return arch
+}
+
+// CacheStats returns the stats of the cache of the architecture and true, or false when the architecture has no cache
+func (arch Architecture) CacheStats() (CacheStats, bool) {
+	if arch.cache == nil {
+		return CacheStats{}, false
+	}
+	return arch.cache.Stats(), true
+}
+
+// Stats returns the hits and misses of the cache since it was created
+func (cache *ResultCache) Stats() CacheStats {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return CacheStats{Hits: cache.hits, Misses: cache.misses}
+}
+
+// check returns true and the cached result of the rule on the packages, the rule is checked and its result is stored
+// on a miss
+func (cache *ResultCache) check(arch Architecture, rule Rule, pkgs ArchPackage) (bool, error) {
+	if cache == nil {
+		return false, rule.check(arch, pkgs)
+	}
+	key, err := fingerprint(rule, pkgs)
+	if err != nil {
+		return false, rule.check(arch, pkgs)
+	}
+	root := arch.RootDir()
+	if data, err := cache.store.Get(key); err == nil {
+		var result cachedResult
+		if err = json.Unmarshal([]byte(strings.ReplaceAll(string(data), rootToken, root)), &result); err == nil {
+			cache.count(true)
+			return true, result.err()
+		}
+	}
+	cache.count(false)
+	err = rule.check(arch, pkgs)
+	result := cachedResult{}
+	if err != nil {
+		result.Error = err.Error()
+		if violations, wrapped := found(err); wrapped {
+			result.Violations = violations
+		}
+	}
+	if data, merr := json.Marshal(result); merr == nil {
+		// the result is still valid when it can not be stored, the rule is just checked again on the next run
+		_ = cache.store.Put(key, []byte(strings.ReplaceAll(string(data), root, rootToken)))
+	}
+	return false, err
+}
+
+func (cache *ResultCache) count(hit bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if hit {
+		cache.hits++
+	} else {
+		cache.misses++
+	}
+}
+
+// err restores the error of the cached result, the violations are wrapped again as the rule wrapped them
+func (result cachedResult) err() error {
+	if result.Error == "" {
+		return nil
+	}
+	if len(result.Violations) == 0 {
+		return errors.New(result.Error)
+	}
+	findings := Findings(result.Violations)
+	prefix, suffix, _ := strings.Cut(result.Error, findings.Error())
+	return fmt.Errorf("%s%w%s", prefix, findings, suffix)
+}
+
+// fingerprint returns the key of the rule on the packages, the content of the go files and the _test.go files are
+// hashed so the key changes with any change of the selected packages, including the tests checked by the rules, eg:
+// ExportedFunctionsShouldHaveTests
+func fingerprint(rule Rule, pkgs ArchPackage) (string, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", rule.name, strings.Join(rule.paths, ","))
+	pkgs = append(ArchPackage{}, pkgs...)
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].ID() < pkgs[j].ID()
+	})
+	for _, pkg := range pkgs {
+		fmt.Fprintf(hash, "%s\n", pkg.ID())
+		files := append(append([]string{}, pkg.GoFiles()...), pkg.TestGoFiles()...)
+		sort.Strings(files)
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(hash, "%s %x\n", filepath.Base(file), sha256.Sum256(data))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type dirStore string
+
+// DirStore returns the store of the files in the directory, the directory is created on the first Put
+func DirStore(dir string) CacheStore {
+	return dirStore(dir)
+}
+
+func (store dirStore) Get(key string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(store), key+".json"))
+}
+
+func (store dirStore) Put(key string, data []byte) error {
+	if err := os.MkdirAll(string(store), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(string(store), key+".json"), data, 0o644)
+}
+
+type httpStore struct {
+	url    string
+	client *http.Client
+}
+
+// HTTPStore returns the store of the http cache server at the url, the results are read by GET and written by PUT
+// of url/key, the same protocol as the http build caches. object storages, eg: S3 or GCS, are used through their
+// http gateways or by implementing CacheStore with their SDKs
+func HTTPStore(url string, client ...*http.Client) CacheStore {
+	store := httpStore{url: strings.TrimSuffix(url, "/"), client: http.DefaultClient}
+	if len(client) > 0 && client[0] != nil {
+		store.client = client[0]
+	}
+	return store
+}
+
+func (store httpStore) Get(key string) ([]byte, error) {
+	resp, err := store.client.Get(fmt.Sprintf("%s/%s", store.url, key))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", key, fs.ErrNotExist)
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(localize("get %s: %s"), key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (store httpStore) Put(key string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%s", store.url, key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp, err := store.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf(localize("put %s: %s"), key, resp.Status)
+	}
+	return nil
+}
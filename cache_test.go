@@ -0,0 +1,121 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestResultCache(t *testing.T) {
+	cache := NewResultCache(t.TempDir())
+	arch := Project().WithCache(cache)
+	checked := 0
+	rule := NewRule("service should not refer controller", func(pkgs ArchPackage) error {
+		checked++
+		return pkgs.ShouldNotReferPkgPaths("sample/controller")
+	}, "sample/service/...")
+	broken := NewRule("layer", func(pkgs ArchPackage) error {
+		checked++
+		return ArchLayer(pkgs).ShouldOnlyReferPackages("sample/model")
+	}, "sample/service")
+	err := arch.Validate(rule, broken)
+	assert.Error(t, err)
+	assert.Equal(t, 2, checked)
+	assert.Equal(t, CacheStats{Hits: 0, Misses: 2}, cache.Stats())
+	cached := arch.Validate(rule, broken)
+	assert.Equal(t, 2, checked)
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 2}, cache.Stats())
+	assert.Equal(t, err.Error(), cached.Error())
+	var violations Violations
+	assert.ErrorAs(t, cached, &violations)
+	assert.Equal(t, "2 rules cached, 2 rules checked", cache.Stats().String())
+	assert.NoError(t, Project().Validate(rule))
+	assert.Equal(t, 3, checked)
+}
+
+func TestHTTPStore(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPut:
+			objects[r.URL.Path], _ = io.ReadAll(r.Body)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+	rule := NewRule("layer", func(pkgs ArchPackage) error {
+		return ArchLayer(pkgs).ShouldOnlyReferPackages("sample/model")
+	}, "sample/service")
+	runner1 := NewStoreCache(HTTPStore(server.URL + "/"))
+	err := Project().WithCache(runner1).Validate(rule)
+	assert.Error(t, err)
+	assert.Equal(t, CacheStats{Misses: 1}, runner1.Stats())
+	assert.Len(t, objects, 1)
+	for _, data := range objects {
+		assert.NotContains(t, string(data), Project().RootDir())
+	}
+	runner2 := NewStoreCache(HTTPStore(server.URL, server.Client()))
+	assert.Equal(t, err.Error(), Project().WithCache(runner2).Validate(rule).Error())
+	assert.Equal(t, CacheStats{Hits: 1}, runner2.Stats())
+	_, err = HTTPStore(server.URL).Get("absent")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = DirStore(t.TempDir()).Get("absent")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestFingerprint_TestFiles(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/fingerprint\n\ngo 1.22\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "api.go"), []byte("package api\n\nfunc Get() {}\n"), 0o644))
+	arch, err := Load(root)
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("...")
+	assert.NoError(t, err)
+	rule := NewRule("tests", func(pkgs ArchPackage) error {
+		return ExportedFunctionsShouldHaveTests(pkgs.Functions())
+	}, "...")
+	untested, err := fingerprint(rule, pkgs)
+	assert.NoError(t, err)
+	test := "package api\n\nimport \"testing\"\n\nfunc TestGet(t *testing.T) { Get() }\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "api_test.go"), []byte(test), 0o644))
+	tested, err := fingerprint(rule, pkgs)
+	assert.NoError(t, err)
+	assert.NotEqual(t, untested, tested)
+}
+
+func TestArchitecture_CacheStats(t *testing.T) {
+	_, ok := Project().CacheStats()
+	assert.False(t, ok)
+	cache := NewResultCache(t.TempDir())
+	arch := Project().WithCache(cache).With(Summary(0, ""))
+	rule := NewRule("layer", func(pkgs ArchPackage) error {
+		return ArchLayer(pkgs).ShouldOnlyReferPackages("sample/model")
+	}, "sample/service")
+	reporter := &recordReporter{}
+	err := arch.ValidateWithReport(reporter, rule)
+	assert.False(t, reporter.results[0].Cached)
+	assert.Contains(t, err.Error(), "0 rules cached, 1 rules checked")
+	err = arch.ValidateWithReport(reporter, rule)
+	assert.True(t, reporter.results[0].Cached)
+	assert.Contains(t, err.Error(), "1 rules cached, 1 rules checked")
+	stats, ok := arch.CacheStats()
+	assert.True(t, ok)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, stats)
+}
@@ -0,0 +1,147 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// CallSite is a call of a declared function or method in the packages of the architecture
+type CallSite = internal.CallSite
+
+// Callers is the call sites of the specified functions
+type Callers []internal.CallSite
+
+// CallersOf returns the call sites of the functions in the project. functions are named as package.Function or
+// package.Type.Method, eg: database/sql.DB.Begin; the leading segments of the package path can be omitted,
+// eg: internal/sample/flags.Enabled
+func CallersOf(functions ...string) Callers {
+	return Project().CallersOf(functions...)
+}
+
+// CallersOf returns the call sites of the functions in the architecture, see the top level one
+func (arch Architecture) CallersOf(functions ...string) Callers {
+	var callers Callers
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		callers = append(callers, lo.Filter(pkg.CallSites(), func(site internal.CallSite, _ int) bool {
+			return funcMatches(site.Callee().Raw(), functions...)
+		})...)
+	})
+	return callers
+}
+
+// ShouldAlsoCall checks every function calls the selected functions also calls at least one of the specified functions
+// in the same function body, eg: callers of database/sql.DB.Begin should also call database/sql.Tx.Commit or
+// database/sql.Tx.Rollback. calls in package level variable initialization are treated as one function
+func (callers Callers) ShouldAlsoCall(functions ...string) error {
+	var result []string
+	lo.ForEach(lo.PartitionBy(callers, func(site internal.CallSite) string {
+		return callerName(site)
+	}), func(sites []internal.CallSite, _ int) {
+		caller, ok := sites[0].Caller()
+		if ok && ignoredFunction("Callers.ShouldAlsoCall", caller) {
+			return
+		}
+		if lo.NoneBy(sites[0].Package().CallSites(), func(site internal.CallSite) bool {
+			return callerName(site) == callerName(sites[0]) && funcMatches(site.Callee().Raw(), functions...)
+		}) {
+			result = append(result, callerName(sites[0]))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("%w do not call any of %v"), Violations(result), functions)).Else(nil)
+}
+
+// FunctionNameMatches matches the functions named as one of the names, the same as CallersOf, eg: sql.DB.Begin
+func FunctionNameMatches(names ...string) Matcher[Function] {
+	return func(f Function) bool {
+		return funcMatches(f.Raw(), names...)
+	}
+}
+
+// FunctionInPackages matches the functions and methods declared in the packages of the paths, eg: sample/repository/...
+func FunctionInPackages(paths ...string) Matcher[Function] {
+	patterns, err := ScopePattern(paths...)
+	return func(f Function) bool {
+		return err == nil && lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(f.Package())
+		})
+	}
+}
+
+// ShouldNotCall checks the functions do not call any function matching one of the matchers directly, eg: handlers
+// should not call FunctionInPackages("sample/repository") even when the imports are legal. calls in the function
+// literals of the functions are calls of the functions
+func (functions Functions) ShouldNotCall(matchers ...Matcher[Function]) error {
+	var result Findings
+	lo.ForEach(functions.uniq(), func(f Function, _ int) {
+		if ignoredFunction("ShouldNotCall", f) {
+			return
+		}
+		lo.ForEach(f.CallSites(), func(site internal.CallSite, _ int) {
+			if lo.SomeBy(matchers, func(matcher Matcher[Function]) bool {
+				return matcher(site.Callee())
+			}) {
+				result = append(result, violationAt(site.Position(), fmt.Sprintf(localize("%s %s calls %s"), site.Position(), f.FullName(), funcName(site.Callee().Raw()))))
+			}
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions call the forbidden functions %w"), result.sorted())).Else(nil)
+}
+
+// ShouldOnlyBeCalledBy checks the functions are only called by the functions matching one of the matchers, the calls
+// in package level variable initialization are called by pkg.init
+func (functions Functions) ShouldOnlyBeCalledBy(matchers ...Matcher[Function]) error {
+	var result Findings
+	if len(functions) == 0 {
+		return nil
+	}
+	lo.ForEach(functions.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			if !functions.contains(site.Callee()) {
+				return
+			}
+			caller, ok := site.Caller()
+			if ok && (ignoredFunction("ShouldOnlyBeCalledBy", caller) || lo.SomeBy(matchers, func(matcher Matcher[Function]) bool {
+				return matcher(caller)
+			})) {
+				return
+			}
+			result = append(result, violationAt(site.Position(), fmt.Sprintf(localize("%s %s calls %s"), site.Position(), callerName(site), funcName(site.Callee().Raw()))))
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions are called by the unexpected functions %w"), result.sorted())).Else(nil)
+}
+
+func callerName(site internal.CallSite) string {
+	if caller, ok := site.Caller(); ok {
+		return caller.FullName()
+	}
+	return fmt.Sprintf("%s.init", site.Package().ID())
+}
+
+// funcName returns the name of the function in the form of package.Function or package.Type.Method
+func funcName(f *types.Func) string {
+	if recv := f.Type().(*types.Signature).Recv(); recv != nil {
+		typ := recv.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if name := typeNameOf(typ); name != nil && name.Pkg() != nil {
+			return fmt.Sprintf("%s.%s.%s", name.Pkg().Path(), name.Name(), f.Name())
+		} else if name != nil {
+			// methods of universe types, eg: error.Error
+			return fmt.Sprintf("%s.%s", name.Name(), f.Name())
+		}
+	}
+	return fmt.Sprintf("%s.%s", f.Pkg().Path(), f.Name())
+}
+
+func funcMatches(f *types.Func, names ...string) bool {
+	name := funcName(f)
+	return lo.SomeBy(names, func(n string) bool {
+		return name == n || strings.HasSuffix(name, "/"+n)
+	})
+}
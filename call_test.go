@@ -0,0 +1,51 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCallersOf(t *testing.T) {
+	assert.Len(t, CallersOf("internal/sample/flags.Enabled"), 2)
+	assert.Len(t, CallersOf("github.com/kcmvp/archunit/internal/sample/flags.Enabled"), 2)
+	assert.Len(t, CallersOf("internal/sample/flags.Client.Enabled"), 1)
+	assert.Len(t, CallersOf("sample/flags.Client.Enabled"), 1)
+	assert.Len(t, CallersOf("flags.Client.Enabled"), 1)
+	assert.Len(t, CallersOf("lags.Client.Enabled"), 0)
+	assert.Len(t, CallersOf("internal/sample/service.NameServiceImpl.FirstNameI"), 1)
+	assert.Len(t, CallersOf("fmt.Println"), 1)
+}
+
+func TestCallers_ShouldAlsoCall(t *testing.T) {
+	callers := CallersOf("internal/sample/flags.Enabled")
+	assert.NoError(t, callers.ShouldAlsoCall("internal/sample/flags.Enabled"))
+	err := callers.ShouldAlsoCall("fmt.Println")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "github.com/kcmvp/archunit/internal/sample/service.AuditCall")
+	assert.NotContains(t, err.Error(), "LoginHandler")
+	assert.NoError(t, callers.ShouldAlsoCall("fmt.Println", "context.Background", "github.com/kcmvp/archunit/internal/sample/flags.Enabled"))
+	assert.NoError(t, CallersOf("fmt.Println").ShouldAlsoCall("internal/sample/flags.Enabled"))
+}
+
+func TestFunctions_ShouldNotCall(t *testing.T) {
+	controller, _ := Layer("sample/controller")
+	functions := append(controller.Functions(), controller.Types().Methods()...)
+	err := functions.ShouldNotCall(FunctionInPackages("sample/flags"), FunctionNameMatches("service.NameServiceImpl.FirstNameI"))
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 2)
+	assert.True(t, strings.HasSuffix(violations[0], "login_controller.go:20:10 (github.com/kcmvp/archunit/internal/sample/controller.LoginController).firstName calls github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl.FirstNameI"))
+	assert.True(t, strings.HasSuffix(violations[1], "login_controller.go:52:5 github.com/kcmvp/archunit/internal/sample/controller.LoginHandler calls github.com/kcmvp/archunit/internal/sample/flags.Enabled"))
+	assert.NoError(t, functions.ShouldNotCall(FunctionInPackages("sample/repository/...")))
+}
+
+func TestFunctions_ShouldOnlyBeCalledBy(t *testing.T) {
+	flags, _ := Packages("sample/flags")
+	err := flags.Functions().ShouldOnlyBeCalledBy(FunctionInPackages("sample/controller"))
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "user_service.go:21:5 github.com/kcmvp/archunit/internal/sample/service.AuditCall calls github.com/kcmvp/archunit/internal/sample/flags.Enabled"))
+	assert.NoError(t, flags.Functions().ShouldOnlyBeCalledBy(FunctionInPackages("sample/controller"), FunctionNameMatches("sample/service.AuditCall")))
+}
@@ -0,0 +1,120 @@
+package archunit
+
+// RuleInfo is the metadata of a built-in rule, rules are named by the function name or Selection.Method
+type RuleInfo struct {
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Parameters  []string `json:"parameters"`
+	Description string   `json:"description"`
+}
+
+// Catalog returns the metadata of all the built-in rules, the categories are the same as the sections of the README:
+// common, layer, package, type, function and source
+func Catalog() []RuleInfo {
+	return []RuleInfo{
+		{"ConstantsShouldBeDefinedInOneFileByPackage", "common", nil, "constants of a package are defined in one file"},
+		{"StableAPIShouldNotDependOnExperimental", "common", nil, "declarations marked stable do not refer to experimental ones"},
+		{"OpenedResourcesShouldBeClosed", "common", nil, "opened files, responses and rows are closed or returned"},
+		{"PackagesWithInitShouldNotDependOnEachOther", "package", nil, "packages with init functions do not depend on each other"},
+		{"ConstructorGraphShouldBeAcyclic", "function", nil, "constructors do not depend on each other circularly"},
+		{"ConstructorsWithMoreThanNParamsShouldUseConfigStruct", "function", []string{"n int", "functions Functions"}, "constructors take at most n parameters"},
+		{"ShouldNotUseLanguageFeaturesBeyond", "common", []string{"goVersion string"}, "language features and standard library packages are not newer than the go version"},
+		{"OSSpecificCallsShouldBeLimitedTo", "common", []string{"paths ...string"}, "platform specific apis are only used in the packages"},
+		{"CustomObjects.NameShould", "common", []string{"pattern NamePattern", "args ...string"}, "custom objects of the registered extractor match the name pattern"},
+		{"CustomObjects.ShouldBeInPackages", "common", []string{"paths ...string"}, "custom objects of the registered extractor are declared in the packages"},
+		{"GoroutinesShouldRecover", "common", []string{"pkgs ArchPackage", "helpers ...string"}, "goroutines of function literals defer a recover"},
+		{"ShouldNotSpawnGoroutinesInLoops", "common", []string{"pkgs ArchPackage"}, "goroutines are not started in loops without a semaphore"},
+		{"PackageInitializationShouldBePure", "package", []string{"pkgs ArchPackage"}, "package variable initializers and init functions do not perform I/O or start goroutines"},
+		{"PackagesShouldExportAtMost", "package", []string{"n int", "pkgs ArchPackage"}, "packages export at most n symbols"},
+		{"PackagesShouldImportAtMost", "package", []string{"n int", "pkgs ArchPackage", "external ...bool"}, "packages import at most n packages of the project"},
+		{"PackagesShouldBeReferredByAtMost", "package", []string{"n int", "pkgs ArchPackage"}, "packages are imported by at most n packages of the project"},
+		{"FeatureFlags.ShouldBeCheckedOnlyIn", "common", []string{"paths ...string"}, "feature flags are only checked in the packages"},
+		{"Callers.ShouldAlsoCall", "common", []string{"functions ...string"}, "callers of the functions also call one of the functions"},
+		{"EnumConstants.SwitchesShouldBeExhaustive", "common", []string{"paths ...string"}, "switches over the constant type handle all the constants"},
+		{"Topics.ShouldMatch", "common", []string{"pattern string"}, "topics discovered by the extractors match the pattern"},
+		{"Topics.OnlyPackagesMayPublishTo", "common", []string{"topicPattern string", "paths ...string"}, "topics of the pattern are only published from the packages"},
+		{"HTTPRoutesShouldBeRegisteredIn", "common", []string{"paths ...string"}, "http routes are only registered in the packages"},
+		{"MiddlewareChains.ShouldBeInOrder", "common", []string{"patterns ...string"}, "middleware chains register the middlewares of the patterns in order"},
+		{"MiddlewareChains.ShouldStartWith", "common", []string{"patterns ...string"}, "middleware chains start with the middleware of the first pattern and register the others in order"},
+		{"ConfigKeysShouldBeDeclaredIn", "common", []string{"paths ...string"}, "configuration keys are constants declared in the packages"},
+		{"UserFacingStringsShouldComeFromMessageCatalog", "common", []string{"pkgs ArchPackage"}, "string literals are not passed to the user facing functions"},
+		{"CoverProfile.PackagesShouldHaveCoverageAtLeast", "common", []string{"pct float64", "pkgs ArchPackage"}, "test coverage of the packages is at least pct"},
+		{"ArchLayer.ShouldNotReferLayers", "layer", []string{"layers ...ArchLayer"}, "layer does not import the layers"},
+		{"ArchLayer.ShouldNotReferPackages", "layer", []string{"paths ...string"}, "layer does not import the packages"},
+		{"ArchLayer.ShouldOnlyReferLayers", "layer", []string{"layers ...ArchLayer"}, "layer only imports the layers"},
+		{"ArchLayer.ShouldOnlyReferPackages", "layer", []string{"paths ...string"}, "layer only imports the packages"},
+		{"ArchLayer.ShouldOnlyReferListedIn", "layer", []string{"file string"}, "layer only imports the patterns listed in the whitelist file"},
+		{"ArchLayer.ShouldBeOnlyReferredByLayers", "layer", []string{"layers ...ArchLayer"}, "layer is only imported by the layers"},
+		{"ArchLayer.ShouldBeOnlyReferredByPackages", "layer", []string{"paths ...string"}, "layer is only imported by the packages"},
+		{"ArchLayer.DepthShouldLessThan", "layer", []string{"depth int"}, "package depth of the layer is less than depth"},
+		{"ArchLayer.ShouldNotExposeTypesFrom", "layer", []string{"modules ...string"}, "exported api of the layer does not refer to the types of the modules"},
+		{"ArchLayer.StringKeysShouldBeTypedConstants", "layer", []string{"callPatterns ...string"}, "keys of context values, sync.Map, headers and metadata are typed constants"},
+		{"LayeredArchitecture.Validate", "layer", nil, "layers only access the lower layers as the access mode allows"},
+		{"LayeredArchitecture.ShouldNotShareCopiedCode", "layer", []string{"minTokens int"}, "no token sequence of minTokens tokens is copied across the layers"},
+		{"LayersShouldMatchManifest", "layer", []string{"path string"}, "layers declared in the code are the same as the layers of the manifest"},
+		{"Architecture.AdhereToPlantUML", "layer", []string{"file string"}, "imports between the components of the PlantUML diagram follow its arrows"},
+		{"LayerConstraint.MayOnlyBeAccessedByLayers", "layer", []string{"names ...string"}, "named layer is only imported by the named layers"},
+		{"LayerConstraint.MayNotBeAccessedByAnyLayer", "layer", nil, "named layer is not imported out of it"},
+		{"LayerConstraint.MayOnlyAccessLayers", "layer", []string{"names ...string"}, "named layer only imports the named layers among the defined layers"},
+		{"ArchPackage.NameShouldBeSameAsFolder", "package", nil, "package name is the same as its folder"},
+		{"ArchPackage.NameShould", "package", []string{"pattern NamePattern", "args ...string"}, "package name matches the pattern"},
+		{"ArchPackage.ShouldNotRefer", "package", []string{"referred ...ArchPackage"}, "packages do not import the packages"},
+		{"ArchPackage.ShouldNotReferPkgPaths", "package", []string{"paths ...string"}, "packages do not import the packages of the paths"},
+		{"ArchPackage.ShouldBeOnlyReferredByPackages", "package", []string{"referrings ...ArchPackage"}, "packages are only imported by the packages"},
+		{"ArchPackage.ShouldBeOnlyReferredByPkgPaths", "package", []string{"paths ...string"}, "packages are only imported by the packages of the paths"},
+		{"ArchPackage.ShouldOnlyReferPackages", "package", []string{"referred ...ArchPackage"}, "packages only import the packages"},
+		{"ArchPackage.ShouldOnlyReferPkgPaths", "package", []string{"paths ...string"}, "packages only import the packages of the paths"},
+		{"ArchPackage.ShouldNotRangeOverMapWhenBuildingOrderedOutput", "package", nil, "exported functions do not build returned slices by ranging over maps"},
+		{"ArchPackage.ShouldUseAtMostSymbolsOf", "package", []string{"target string", "n int"}, "packages use at most n distinct symbols of the target packages"},
+		{"ArchPackage.ShouldBeFreeOfCycles", "package", nil, "packages and their sub packages do not depend on each other circularly"},
+		{"ArchPackage.VersionedPackagesShouldBeAliasedAs", "package", []string{"pattern string"}, "versioned packages of the module are imported by the aliases matching the pattern"},
+		{"ArchPackage.OnlyAllowedExternalDependencies", "package", []string{"modules ...string"}, "packages only import the third-party packages of the allowed modules"},
+		{"ArchPackage.ForbiddenExternalDependencies", "package", []string{"modules ...string"}, "packages do not import the third-party packages of the forbidden modules"},
+		{"ArchPackage.ShouldNotImport", "package", []string{"imports ...string"}, "packages do not import the packages, the deprecated packages by default"},
+		{"FolderContent.Validate", "package", []string{"pkgs ...ArchPackage"}, "package folders only contain go files and the allowed extensions"},
+		{"ProjectTreeShouldBePortable", "source", nil, "project tree has no symbolic links or names differing only by case"},
+		{"PathsShouldBeShorterThan", "source", []string{"n int"}, "paths of the project tree relative to the root are shorter than n"},
+		{"PathsShouldMatch", "source", []string{"pattern string"}, "names of the files and folders of the project tree match the pattern"},
+		{"ArchDiff.ShouldNotAddDependenciesBetween", "package", []string{"pathA string", "pathB string"}, "no new dependency is added between the packages since the compared architecture"},
+		{"Types.MethodShouldBeDefinedInOneFile", "type", nil, "methods of a type are defined in one file"},
+		{"Types.ShouldBe", "type", []string{"visible Visible"}, "types are of the visibility"},
+		{"Types.ShouldBeInPackages", "type", []string{"pkgs ...string"}, "types are declared in the packages"},
+		{"Types.NameShould", "type", []string{"pattern NamePattern", "args ...string"}, "type names match the pattern"},
+		{"Types.ShouldOnlyBeConstructedIn", "type", []string{"layers ...ArchLayer"}, "types are only constructed by composite literals in the layers"},
+		{"ConstantSelection.ShouldBeExported", "common", nil, "constants are exported"},
+		{"ConstantSelection.ShouldResideInPackages", "common", []string{"paths ...string"}, "constants are declared in the packages"},
+		{"ConstantSelection.NameShould", "common", []string{"pattern NamePattern", "args ...string"}, "constant names match the pattern"},
+		{"Types.ShouldNotBeTypeAsserted", "type", []string{"paths ...string"}, "interfaces are not type asserted out of the packages"},
+		{"Types.FieldsShouldNotBeExported", "type", nil, "fields of the structs are not exported"},
+		{"Types.ShouldNotHaveFieldOfType", "type", []string{"typNames ...string"}, "structs have no field of the types or their pointers"},
+		{"Types.TagsShould", "type", []string{"key string", "pattern NamePattern", "args ...string"}, "names of the key in the tags of the struct fields match the pattern"},
+		{"Types.ShouldHaveTag", "type", []string{"key string"}, "exported fields of the structs have the key in their tags"},
+		{"Types.ShouldNotHaveTag", "type", []string{"key string"}, "fields of the structs do not have the key in their tags"},
+		{"EveryTypeShouldBeClassified", "type", []string{"classifiers map[string]Matcher[Type]"}, "exported types of the project match at least one of the role matchers"},
+		{"Types.ShouldBeClassified", "type", []string{"classifiers map[string]Matcher[Type]"}, "exported types match at least one of the role matchers"},
+		{"TypesIntendedToImplement", "type", []string{"iface string", "matcher Matcher[Type]"}, "types matching the matcher implement the interface"},
+		{"Types.ImplementationsShouldBeRegisteredIn", "type", []string{"registryFunc string"}, "implementers of the interfaces are passed to the registry function"},
+		{"Functions.NameShould", "function", []string{"pattern NamePattern", "args ...string"}, "function names match the pattern"},
+		{"Functions.ShouldHaveLinesLessThan", "function", []string{"n int"}, "declarations of the functions span less than n lines"},
+		{"Functions.ShouldHaveAtMostParams", "function", []string{"n int"}, "functions take at most n parameters"},
+		{"Functions.ShouldHaveAtMostReturns", "function", []string{"n int"}, "functions return at most n results"},
+		{"Functions.ShouldNotCall", "function", []string{"matchers ...Matcher[Function]"}, "functions do not call the functions matching the matchers"},
+		{"Functions.ShouldOnlyBeCalledBy", "function", []string{"matchers ...Matcher[Function]"}, "functions are only called by the functions matching the matchers"},
+		{"ExportedFunctionsShouldHaveTests", "function", []string{"functions Functions"}, "exported functions are referred by tests"},
+		{"ExportedFunctionsShouldNotPanic", "function", []string{"functions Functions"}, "exported functions do not call panic"},
+		{"ExportedFunctionsShouldNotExposeChannels", "function", []string{"functions Functions", "streaming ...string"}, "exported functions do not have channel parameters or results"},
+		{"ExportedCollectionsShouldReturnIterators", "function", []string{"functions Functions", "pattern string"}, "exported functions of the pattern return iterators rather than slices"},
+		{"ContextShouldBeFirstParam", "function", []string{"functions Functions"}, "functions take context.Context as the first parameter"},
+		{"ErrorShouldBeLastReturn", "function", []string{"functions Functions"}, "functions return error as the last result"},
+		{"ExportedServiceMethodsShouldStartSpans", "function", []string{"services Types", "spanFuncPattern string"}, "exported methods of the services call the span function"},
+		{"UnitTestsShouldNotTouchNetworkOrFilesystem", "function", []string{"pkgs ArchPackage"}, "unit tests do not call network clients, commands or absolute path files"},
+		{"TestsShouldCallParallel", "function", []string{"pkgs ArchPackage", "exceptions ...string"}, "test functions call t.Parallel"},
+		{"TestsShouldUseAssertionLibrary", "function", []string{"allowed ...string"}, "tests only import the allowed assertion and mocking libraries"},
+		{"BenchmarksAndFuzzTestsShouldResideIn", "function", []string{"paths ...string"}, "benchmarks and fuzz tests reside in the packages"},
+		{"ExamplesShouldReferenceExistingIdentifiers", "function", nil, "examples are named after existing identifiers"},
+		{"SourceNameShould", "source", []string{"pattern NamePattern", "args ...string"}, "source file names match the pattern"},
+		{"TopLevelPackagesShouldHaveDoc", "source", []string{"pkgs ArchPackage", "fileNames ...string"}, "top level packages have a doc.go or README"},
+		{"MigrationsShouldBeSequential", "source", []string{"folder string", "pattern string"}, "migration files are numbered sequentially"},
+		{"MigrationsShouldNotBeModified", "source", []string{"baselineStore string"}, "migration files recorded in the baseline are not modified"},
+	}
+}
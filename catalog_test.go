@@ -0,0 +1,36 @@
+package archunit
+
+import (
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := Catalog()
+	assert.Len(t, lo.UniqBy(catalog, func(rule RuleInfo) string {
+		return rule.Name
+	}), len(catalog))
+	scope := internal.Arch().Package("github.com/kcmvp/archunit").Raw().Types.Scope()
+	lo.ForEach(catalog, func(rule RuleInfo, _ int) {
+		assert.Contains(t, []string{"common", "layer", "package", "type", "function", "source"}, rule.Category)
+		assert.NotEmpty(t, rule.Description)
+		var f *types.Func
+		if typ, method, ok := strings.Cut(rule.Name, "."); ok {
+			obj, _, _ := types.LookupFieldOrMethod(scope.Lookup(typ).Type(), false, scope.Lookup(typ).Pkg(), method)
+			f, _ = obj.(*types.Func)
+		} else {
+			f, _ = scope.Lookup(rule.Name).(*types.Func)
+		}
+		if assert.NotNil(t, f, rule.Name) {
+			sig := f.Type().(*types.Signature)
+			assert.Equal(t, sig.Params().Len(), len(rule.Parameters), rule.Name)
+			for i := 0; i < sig.Params().Len(); i++ {
+				assert.True(t, strings.HasPrefix(rule.Parameters[i], sig.Params().At(i).Name()+" "), rule.Name)
+			}
+		}
+	})
+}
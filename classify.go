@@ -0,0 +1,81 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"regexp"
+	"sort"
+)
+
+// Type is a type declared in the packages of the architecture
+type Type = internal.Type
+
+// Matcher reports whether the item plays a role of the architecture, eg: a controller or a repository
+type Matcher[T any] func(item T) bool
+
+// TypeNameMatches matches the types whose full names match the pattern, eg: TypeNameMatches(HaveSuffix, "Repository")
+func TypeNameMatches(pattern NamePattern, arg string) Matcher[Type] {
+	return func(typ Type) bool {
+		return pattern(typ.Name(), arg)
+	}
+}
+
+// TypeInPackages matches the types declared in the packages of the paths
+func TypeInPackages(paths ...string) Matcher[Type] {
+	patterns, err := ScopePattern(paths...)
+	return func(typ Type) bool {
+		return err == nil && lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(typ.Package())
+		})
+	}
+}
+
+// Interfaces returns the interfaces of the project matching all the matchers
+func Interfaces(matchers ...Matcher[Type]) Types {
+	return AllPackages().Interfaces(matchers...)
+}
+
+// Structs returns the structs of the project matching all the matchers
+func Structs(matchers ...Matcher[Type]) Types {
+	return AllPackages().Structs(matchers...)
+}
+
+// Interfaces returns the interfaces of the packages matching all the matchers
+func (archPkg ArchPackage) Interfaces(matchers ...Matcher[Type]) Types {
+	return archPkg.Types().Matching(append([]Matcher[Type]{Type.Interface}, matchers...)...)
+}
+
+// Structs returns the structs of the packages matching all the matchers
+func (archPkg ArchPackage) Structs(matchers ...Matcher[Type]) Types {
+	return archPkg.Types().Matching(append([]Matcher[Type]{Type.Struct}, matchers...)...)
+}
+
+// Matching returns the types of the selection matching all the matchers
+func (types Types) Matching(matchers ...Matcher[Type]) Types {
+	return lo.Filter(types, func(typ internal.Type, _ int) bool {
+		return lo.EveryBy(matchers, func(matcher Matcher[Type]) bool {
+			return matcher(typ)
+		})
+	})
+}
+
+// EveryTypeShouldBeClassified checks every exported type of the project plays at least one of the roles, the roles are
+// keyed by their names, eg: map[string]Matcher[Type]{"controller": TypeInPackages("sample/controller/..."), ...}. it
+// keeps the taxonomy of the architecture complete as the code grows
+func EveryTypeShouldBeClassified(classifiers map[string]Matcher[Type]) error {
+	return AppTypes().ShouldBeClassified(classifiers)
+}
+
+// ShouldBeClassified checks every exported type of the types plays at least one of the roles, see
+// EveryTypeShouldBeClassified
+func (types Types) ShouldBeClassified(classifiers map[string]Matcher[Type]) error {
+	roles := lo.Keys(classifiers)
+	sort.Strings(roles)
+	result := lo.FilterMap(types, func(typ internal.Type, _ int) (Violation, bool) {
+		return violationAt(typ.Position(), typ.Name()), typ.Exported() && lo.NoneBy(roles, func(role string) bool {
+			return classifiers[role](typ)
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("types are not any of %v: %w"), roles, Findings(result).sorted())).Else(nil)
+}
@@ -0,0 +1,50 @@
+package archunit
+
+import (
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTypes_ShouldBeClassified(t *testing.T) {
+	types := AppTypes().InPackages("internal/sample/repository", "internal/sample/repository/ext")
+	err := types.ShouldBeClassified(map[string]Matcher[Type]{
+		"repository": TypeNameMatches(HaveSuffix, "Repository"),
+	})
+	assert.EqualError(t, err, "types are not any of [repository]: [github.com/kcmvp/archunit/internal/sample/repository.FF github.com/kcmvp/archunit/internal/sample/repository/ext.UserRepositoryExt]")
+	assert.NoError(t, types.ShouldBeClassified(map[string]Matcher[Type]{
+		"repository": TypeNameMatches(HaveSuffix, "Repository"),
+		"extension":  TypeInPackages("internal/sample/repository/ext"),
+		"constant": func(typ Type) bool {
+			return typ.Name() == "github.com/kcmvp/archunit/internal/sample/repository.FF"
+		},
+	}))
+	assert.Error(t, EveryTypeShouldBeClassified(map[string]Matcher[Type]{
+		"controller": TypeInPackages("internal/sample/controller/..."),
+	}))
+	assert.NoError(t, EveryTypeShouldBeClassified(map[string]Matcher[Type]{
+		"any": TypeInPackages("..."),
+	}))
+}
+
+func TestInterfacesAndStructs(t *testing.T) {
+	names := func(types Types) []string {
+		return lo.Map(types, func(typ internal.Type, _ int) string {
+			return typ.Name()
+		})
+	}
+	pkgs, _ := Packages("sample/service")
+	assert.Equal(t, []string{"github.com/kcmvp/archunit/internal/sample/service.NameService"}, names(pkgs.Interfaces()))
+	assert.ElementsMatch(t, []string{
+		"github.com/kcmvp/archunit/internal/sample/service.FullNameImpl",
+		"github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl",
+		"github.com/kcmvp/archunit/internal/sample/service.UserService",
+	}, names(pkgs.Structs()))
+	assert.Equal(t, []string{"github.com/kcmvp/archunit/internal/sample/service.UserService"}, names(pkgs.Structs(TypeNameMatches(HaveSuffix, "Service"))))
+	assert.Contains(t, names(Interfaces(TypeInPackages("internal/sample/..."))), "github.com/kcmvp/archunit/internal/sample/service.NameService")
+	assert.True(t, lo.EveryBy(Structs(), func(typ internal.Type) bool {
+		return typ.Struct() && !typ.Interface()
+	}))
+	assert.Empty(t, Interfaces(TypeNameMatches(HaveSuffix, "Impl")))
+}
@@ -0,0 +1,88 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// cloneToken is a normalized token of the source code, identifiers and literals are normalized to their kinds
+// so the copies with renamed variables are detected as well
+type cloneToken struct {
+	text     string
+	position token.Position
+}
+
+// ShouldNotShareCopiedCode checks no sequence of at least minTokens tokens in the declarations of a layer is copied
+// to another layer, the shared logic should be moved to a common package instead. identifiers and literals are
+// normalized, each pair of files is reported once at the first copied sequence. minTokens must be positive
+func (arch LayeredArchitecture) ShouldNotShareCopiedCode(minTokens int) error {
+	if minTokens <= 0 {
+		return fmt.Errorf(localize("invalid minimum tokens %d"), minTokens)
+	}
+	seen := map[string]lo.Tuple2[int, token.Position]{}
+	reported := map[string]bool{}
+	var result Findings
+	lo.ForEach(arch.layers, func(layer ArchLayer, i int) {
+		lo.ForEach(layer, func(pkg *internal.Package, _ int) {
+			lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+				tokens := cloneTokens(pkg.Raw().Fset, file)
+				for start := 0; start+minTokens <= len(tokens); start++ {
+					key := strings.Join(lo.Map(tokens[start:start+minTokens], func(tok cloneToken, _ int) string {
+						return tok.text
+					}), " ")
+					first, ok := seen[key]
+					if !ok {
+						seen[key] = lo.T2(i, tokens[start].position)
+						continue
+					}
+					pair := fmt.Sprintf("%s %s", first.B.Filename, tokens[start].position.Filename)
+					if first.A != i && !reported[pair] {
+						reported[pair] = true
+						result = append(result, violationAt(tokens[start].position, fmt.Sprintf(localize("%s is copied to %s"), first.B, tokens[start].position)))
+					}
+				}
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("layers share copied code: %w"), result.sorted())).Else(nil)
+}
+
+// cloneTokens returns the normalized tokens of the declarations except the imports of the file
+func cloneTokens(fset *token.FileSet, file *ast.File) []cloneToken {
+	decl, ok := lo.Find(file.Decls, func(decl ast.Decl) bool {
+		gen, ok := decl.(*ast.GenDecl)
+		return !ok || gen.Tok != token.IMPORT
+	})
+	if !ok {
+		return nil
+	}
+	tf := fset.File(file.Pos())
+	src, err := os.ReadFile(tf.Name())
+	if err != nil {
+		return nil
+	}
+	var s scanner.Scanner
+	s.Init(tf, src, nil, 0)
+	var tokens []cloneToken
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if pos < decl.Pos() || (tok == token.SEMICOLON && lit == "\n") {
+			continue
+		}
+		text := tok.String()
+		if tok == token.IDENT || tok.IsLiteral() {
+			text = lo.If(tok == token.IDENT, "id").Else("lit")
+		}
+		tokens = append(tokens, cloneToken{text: text, position: fset.Position(pos)})
+	}
+	return tokens
+}
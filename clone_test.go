@@ -0,0 +1,27 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLayeredArchitecture_ShouldNotShareCopiedCode(t *testing.T) {
+	arch, err := Load("testdata/clone")
+	assert.NoError(t, err)
+	controller, err := arch.Packages("clone/controller")
+	assert.NoError(t, err)
+	service, err := arch.Packages("clone/service")
+	assert.NoError(t, err)
+	layers := Layers(ArchLayer(controller), ArchLayer(service))
+	assert.NoError(t, layers.ShouldNotShareCopiedCode(80))
+	err = layers.ShouldNotShareCopiedCode(40)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.Contains(violations[0], "controller/controller.go:8:29 is copied to "), violations[0])
+	assert.True(t, strings.HasSuffix(violations[0], "service/service.go:5:34"), violations[0])
+	sample, _ := Layer("sample/...")
+	assert.NoError(t, Layers(sample).ShouldNotShareCopiedCode(10))
+	assert.ErrorContains(t, layers.ShouldNotShareCopiedCode(0), "invalid minimum tokens 0")
+}
@@ -0,0 +1,71 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+	"regexp"
+)
+
+var viperGetters = []string{"Get", "GetString", "GetBool", "GetInt", "GetInt32", "GetInt64", "GetUint", "GetUint32", "GetUint64",
+	"GetFloat64", "GetDuration", "GetTime", "GetIntSlice", "GetStringSlice", "GetStringMap", "GetStringMapString",
+	"GetStringMapStringSlice", "GetSizeInBytes", "IsSet", "Sub"}
+
+// ConfigFunctions is the functions reading the configuration by the key of the first argument, named as CallersOf.
+// it covers os and viper by default, append the functions of other configuration libraries to it
+var ConfigFunctions = lo.Flatten([][]string{
+	{"os.Getenv", "os.LookupEnv"},
+	methodsOf("github.com/spf13/viper", viperGetters...),
+	methodsOf("github.com/spf13/viper.Viper", viperGetters...),
+})
+
+// ConfigKeys returns the inventory of the constant configuration keys read by ConfigFunctions in the project,
+// keyed by the configuration key with the call sites as the value
+func ConfigKeys() map[string][]string {
+	keys := map[string][]string{}
+	lo.ForEach(TopicsOf(StringArg(0, ConfigFunctions...)), func(topic Topic, _ int) {
+		keys[topic.Name] = append(keys[topic.Name], callSite(topic.CallSite))
+	})
+	return keys
+}
+
+// ConfigKeysShouldBeDeclaredIn checks the configuration keys read by ConfigFunctions are constants declared in the
+// packages of the paths, string literal keys are only allowed in these packages
+func ConfigKeysShouldBeDeclaredIn(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	declared := func(path string) bool {
+		return lo.SomeBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(path)
+		})
+	}
+	var result Findings
+	lo.ForEach(CallersOf(ConfigFunctions...), func(site internal.CallSite, _ int) {
+		if len(site.Expr().Args) == 0 || declared(site.Package().ID()) {
+			return
+		}
+		if caller, ok := site.Caller(); ok && ignoredFunction("ConfigKeysShouldBeDeclaredIn", caller) {
+			return
+		}
+		var id *ast.Ident
+		switch key := ast.Unparen(site.Expr().Args[0]).(type) {
+		case *ast.BasicLit:
+			result = append(result, violationAt(site.Position(), fmt.Sprintf("%s %s", callSite(site), key.Value)))
+			return
+		case *ast.Ident:
+			id = key
+		case *ast.SelectorExpr:
+			id = key.Sel
+		default:
+			return
+		}
+		if c, ok := site.Package().Raw().TypesInfo.Uses[id].(*types.Const); ok && c.Pkg() != nil && !declared(c.Pkg().Path()) {
+			result = append(result, violationAt(site.Position(), fmt.Sprintf("%s %s", callSite(site), qualifiedName(c))))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("config keys are not declared in %v: %w"), paths, result.sorted())).Else(nil)
+}
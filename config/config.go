@@ -0,0 +1,196 @@
+// Package config loads the architecture rules declared in archunit.yaml, so the policy of the architecture is managed
+// out of the go code, eg:
+//
+//	layers:
+//	  controller: [sample/controller/...]
+//	  service: [sample/service/...]
+//	rules:
+//	  - layer: service
+//	    shouldNotRefer: [controller]
+//	  - paths: [sample/...]
+//	    nameShould: lower_case
+//	bestPractices:
+//	  freeOfCycles: true
+package config
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Config is the layers, the rules and the best practices of archunit.yaml
+type Config struct {
+	Layers        map[string][]string `yaml:"layers"`
+	Rules         []RuleConfig        `yaml:"rules"`
+	BestPractices BestPractices       `yaml:"bestPractices"`
+}
+
+// RuleConfig is a rule on the packages of the layer or the paths, every check of the rule is a separate Rule. the
+// packages referred by ShouldNotRefer and ShouldOnlyRefer are layer names or package paths. the name is the name of
+// the Rule of a single check, and prefixes the descriptions of the checks when there are several, so the names are
+// unique
+type RuleConfig struct {
+	Name            string   `yaml:"name"`
+	Layer           string   `yaml:"layer"`
+	Paths           []string `yaml:"paths"`
+	ShouldNotRefer  []string `yaml:"shouldNotRefer"`
+	ShouldOnlyRefer []string `yaml:"shouldOnlyRefer"`
+	NameShould      string   `yaml:"nameShould"`
+	MaxDepth        int      `yaml:"maxDepth"`
+	Category        string   `yaml:"category"`
+	Because         string   `yaml:"because"`
+}
+
+// BestPractices toggles the built-in rules applied on all the packages
+type BestPractices struct {
+	FreeOfCycles       bool `yaml:"freeOfCycles"`
+	NameSameAsFolder   bool `yaml:"nameSameAsFolder"`
+	ConstantsInOneFile bool `yaml:"constantsInOneFile"`
+}
+
+// namings are the name patterns of NameShould, the patterns with argument are written as prefix:arg or suffix:arg
+var namings = map[string]archunit.NamePattern{
+	"lower_case": archunit.BeLowerCase,
+	"upper_case": archunit.BeUpperCase,
+	"prefix":     archunit.HavePrefix,
+	"suffix":     archunit.HaveSuffix,
+}
+
+// Load reads the configuration file, eg: archunit.yaml
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return Parse(data)
+}
+
+// Rules returns the rules of the configuration file, eg: rules, err := config.Rules("archunit.yaml")
+func Rules(path string) ([]archunit.Rule, error) {
+	config, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.Build()
+}
+
+// Parse parses the yaml configuration
+func Parse(data []byte) (Config, error) {
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return config, nil
+}
+
+// Build returns the rules of the configuration, the rules of the best practices come first. it returns an error for
+// the undefined layers and the unknown name patterns
+func (config Config) Build() ([]archunit.Rule, error) {
+	var rules []archunit.Rule
+	if config.BestPractices.FreeOfCycles {
+		rules = append(rules, archunit.NewRule("packages should be free of cycles", func(pkgs archunit.ArchPackage) error {
+			return pkgs.ShouldBeFreeOfCycles()
+		}, "...").WithCategory("package"))
+	}
+	if config.BestPractices.NameSameAsFolder {
+		rules = append(rules, archunit.NewRule("package names should be same as folders", func(pkgs archunit.ArchPackage) error {
+			return pkgs.NameShouldBeSameAsFolder()
+		}, "...").WithCategory("package"))
+	}
+	if config.BestPractices.ConstantsInOneFile {
+		rules = append(rules, archunit.NewRule("constants should be defined in one file by package", func(pkgs archunit.ArchPackage) error {
+			return pkgs.ConstantsShouldBeDefinedInOneFile()
+		}, "...").WithCategory("common"))
+	}
+	for i, rc := range config.Rules {
+		paths, err := config.selection(rc)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		checks, err := config.checks(rc)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		if len(checks) == 0 {
+			return nil, fmt.Errorf("rule %d: no check is configured", i+1)
+		}
+		subject := lo.If(rc.Layer != "", rc.Layer).ElseF(func() string {
+			return strings.Join(rc.Paths, ",")
+		})
+		names := lo.Keys(checks)
+		sort.Strings(names)
+		prefix := lo.If(rc.Name != "", rc.Name).Else(subject)
+		for _, name := range names {
+			rule := archunit.NewRule(lo.If(rc.Name != "" && len(names) == 1, rc.Name).Else(fmt.Sprintf("%s %s", prefix, name)), checks[name], paths...)
+			rules = append(rules, rule.WithCategory(rc.Category).Because(rc.Because))
+		}
+	}
+	return rules, nil
+}
+
+// selection returns the paths of the packages the rule checks
+func (config Config) selection(rc RuleConfig) ([]string, error) {
+	if rc.Layer == "" {
+		if len(rc.Paths) == 0 {
+			return nil, fmt.Errorf("neither layer nor paths is configured")
+		}
+		return rc.Paths, nil
+	}
+	paths, ok := config.Layers[rc.Layer]
+	if !ok {
+		return nil, fmt.Errorf("undefined layer %s", rc.Layer)
+	}
+	return append(append([]string{}, paths...), rc.Paths...), nil
+}
+
+// resolve returns the paths of the layer names, the items which are not layer names are paths
+func (config Config) resolve(items []string) []string {
+	return lo.FlatMap(items, func(item string, _ int) []string {
+		return lo.If(config.Layers[item] != nil, config.Layers[item]).Else([]string{item})
+	})
+}
+
+// checks returns the checks of the rule keyed by their descriptions
+func (config Config) checks(rc RuleConfig) (map[string]func(pkgs archunit.ArchPackage) error, error) {
+	checks := map[string]func(pkgs archunit.ArchPackage) error{}
+	if len(rc.ShouldNotRefer) > 0 {
+		paths := config.resolve(rc.ShouldNotRefer)
+		checks[fmt.Sprintf("should not refer %v", rc.ShouldNotRefer)] = func(pkgs archunit.ArchPackage) error {
+			return pkgs.ShouldNotReferPkgPaths(paths...)
+		}
+	}
+	if len(rc.ShouldOnlyRefer) > 0 {
+		paths := config.resolve(rc.ShouldOnlyRefer)
+		checks[fmt.Sprintf("should only refer %v", rc.ShouldOnlyRefer)] = func(pkgs archunit.ArchPackage) error {
+			return pkgs.ShouldOnlyReferPkgPaths(paths...)
+		}
+	}
+	if rc.NameShould == "same_as_folder" {
+		checks["name should be same as folder"] = func(pkgs archunit.ArchPackage) error {
+			return pkgs.NameShouldBeSameAsFolder()
+		}
+	} else if rc.NameShould != "" {
+		name, arg, _ := strings.Cut(rc.NameShould, ":")
+		pattern, ok := namings[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown name pattern %s", rc.NameShould)
+		}
+		checks[fmt.Sprintf("name should be %s", rc.NameShould)] = func(pkgs archunit.ArchPackage) error {
+			return pkgs.NameShould(pattern, arg)
+		}
+	}
+	if rc.MaxDepth > 0 {
+		checks[fmt.Sprintf("depth should be less than %d", rc.MaxDepth)] = func(pkgs archunit.ArchPackage) error {
+			if len(pkgs) == 0 {
+				return nil
+			}
+			return archunit.ArchLayer(pkgs).DepthShouldLessThan(rc.MaxDepth)
+		}
+	}
+	return checks, nil
+}
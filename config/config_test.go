@@ -0,0 +1,103 @@
+package config
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	rules, err := Rules("testdata/archunit.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"package names should be same as folders",
+		"service should not refer [controller]",
+		"controller should not access repository",
+		"sample/... name should be lower_case",
+		"repository depth should be less than 3",
+	}, lo.Map(rules, func(rule archunit.Rule, _ int) string {
+		return rule.Name()
+	}))
+	assert.Equal(t, []string{"sample/controller", "sample/controller/..."}, rules[2].Paths())
+	assert.Equal(t, "layer", rules[2].Category())
+	assert.Equal(t, "controllers go through services", rules[2].Rationale())
+	arch := archunit.Project()
+	assert.NoError(t, arch.Validate(rules[1], rules[3]))
+	assert.ErrorContains(t, arch.Validate(rules[2]), "controller should not access repository: github.com/kcmvp/archunit/internal/sample/controller/module1 referrs")
+	assert.ErrorContains(t, arch.Validate(rules[4]), "repository depth should be less than 3: github.com/kcmvp/archunit/internal/sample/repository max depth is 6")
+}
+
+func TestConfig_Build(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "undefined layer",
+			config: "rules:\n  - layer: service\n    maxDepth: 3",
+			err:    "rule 1: undefined layer service",
+		},
+		{
+			name:   "no selection",
+			config: "rules:\n  - maxDepth: 3",
+			err:    "rule 1: neither layer nor paths is configured",
+		},
+		{
+			name:   "no check",
+			config: "rules:\n  - paths: [sample/...]",
+			err:    "rule 1: no check is configured",
+		},
+		{
+			name:   "unknown name pattern",
+			config: "rules:\n  - paths: [sample/...]\n    nameShould: camel_case",
+			err:    "rule 1: unknown name pattern camel_case",
+		},
+		{
+			name:   "prefix",
+			config: "rules:\n  - paths: [sample/...]\n    nameShould: prefix:s",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := Parse([]byte(test.config))
+			assert.NoError(t, err)
+			_, err = config.Build()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+	_, err := Parse([]byte("rules: ["))
+	assert.ErrorContains(t, err, "invalid configuration")
+	_, err = Rules("testdata/absent.yaml")
+	assert.Error(t, err)
+}
+
+func TestConfig_BestPractices(t *testing.T) {
+	config, err := Parse([]byte("bestPractices:\n  constantsInOneFile: true"))
+	assert.NoError(t, err)
+	rules, err := config.Build()
+	assert.NoError(t, err)
+	assert.Error(t, archunit.Project().Validate(rules...))
+	// the rules check the architecture they run on rather than the current module
+	arch, err := archunit.Load("../testdata/cycle")
+	assert.NoError(t, err)
+	assert.NoError(t, arch.Validate(rules...))
+}
+
+func TestConfig_Names(t *testing.T) {
+	config, err := Parse([]byte("rules:\n  - name: service rules\n    paths: [sample/service/...]\n    maxDepth: 3\n    nameShould: lower_case"))
+	assert.NoError(t, err)
+	rules, err := config.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"service rules depth should be less than 3",
+		"service rules name should be lower_case",
+	}, lo.Map(rules, func(rule archunit.Rule, _ int) string {
+		return rule.Name()
+	}))
+}
@@ -0,0 +1,24 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestConfigKeys(t *testing.T) {
+	keys := ConfigKeys()
+	assert.Len(t, keys, 1)
+	assert.Len(t, keys["STORAGE_BUCKET"], 1)
+	assert.True(t, strings.HasSuffix(keys["STORAGE_BUCKET"][0], "github.com/kcmvp/archunit/internal/sample/service/thirdparty.bucket -> os.Getenv"))
+}
+
+func TestConfigKeysShouldBeDeclaredIn(t *testing.T) {
+	assert.NoError(t, ConfigKeysShouldBeDeclaredIn("sample/service/thirdparty"))
+	err := ConfigKeysShouldBeDeclaredIn("sample/flags")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "thirdparty.bucket -> os.Getenv \"STORAGE_BUCKET\""))
+	assert.Error(t, ConfigKeysShouldBeDeclaredIn("sample/[a"))
+}
@@ -0,0 +1,155 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+)
+
+// EnumConstants is the enum style constant set of a type, eg: `const ( F1 FF = iota; F2 )`
+type EnumConstants []Constant
+
+// ConstantsOfType returns the constants of the specified type declared in the same package of the type
+func ConstantsOfType(typName string) (EnumConstants, error) {
+	return Project().ConstantsOfType(typName)
+}
+
+// ConstantsOfType returns the constants of the specified type of the architecture, see the top level one
+func (arch Architecture) ConstantsOfType(typName string) (EnumConstants, error) {
+	typ, ok := arch.artifact.Type(typName)
+	if !ok {
+		return EnumConstants{}, fmt.Errorf(localize("can not find type %s"), typName)
+	}
+	pkg := arch.artifact.Package(typ.Package())
+	if pkg == nil {
+		return EnumConstants{}, nil
+	}
+	return lo.Filter(pkg.Constants(), func(c internal.Constant, _ int) bool {
+		return types.Identical(c.Raw().Type(), typ.Raw())
+	}), nil
+}
+
+// SwitchesShouldBeExhaustive checks every switch statement over the constant type has a case for each constant
+// of the set, a default clause does not make a switch exhaustive, so adding a new constant fails the rule until
+// all the switches handle it. only the packages of the specified paths are checked when paths are supplied.
+func (constants EnumConstants) SwitchesShouldBeExhaustive(paths ...string) error {
+	if len(constants) == 0 {
+		return nil
+	}
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	typ := constants[0].Raw().Type()
+	var result Findings
+	lo.ForEach(constants[0].Artifact().Packages(), func(pkg *internal.Package, _ int) {
+		if len(patterns) > 0 && !lo.SomeBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(pkg.ID())
+		}) {
+			return
+		}
+		info := pkg.Raw().TypesInfo
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			ast.Inspect(file, func(node ast.Node) bool {
+				stmt, ok := node.(*ast.SwitchStmt)
+				if !ok || stmt.Tag == nil || !types.Identical(info.TypeOf(stmt.Tag), typ) {
+					return true
+				}
+				var values []constant.Value
+				lo.ForEach(stmt.Body.List, func(clause ast.Stmt, _ int) {
+					lo.ForEach(clause.(*ast.CaseClause).List, func(expr ast.Expr, _ int) {
+						if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+							values = append(values, tv.Value)
+						}
+					})
+				})
+				missing := lo.FilterMap(constants, func(c Constant, _ int) (string, bool) {
+					return c.Name(), !lo.SomeBy(values, func(value constant.Value) bool {
+						return constant.Compare(value, token.EQL, c.Raw().Val())
+					})
+				})
+				if len(missing) > 0 {
+					position := pkg.Raw().Fset.Position(stmt.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf("%s misses %v", position, missing)))
+				}
+				return true
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("switches over %s are not exhaustive: %w"), typ, result.sorted())).Else(nil)
+}
+
+// Constant is a package level constant of the project
+type Constant = internal.Constant
+
+// ConstantSelection is the package level constants selected by Constants or ArchPackage.Constants
+type ConstantSelection []internal.Constant
+
+// Constants returns the package level constants of the project matching all the matchers, eg:
+// Constants(ConstantNameMatches(HavePrefix, "Default"))
+func Constants(matchers ...Matcher[Constant]) ConstantSelection {
+	return Project().Constants(matchers...)
+}
+
+// Constants returns the package level constants of the architecture matching all the matchers
+func (arch Architecture) Constants(matchers ...Matcher[Constant]) ConstantSelection {
+	return ArchPackage(arch.artifact.Packages()).Constants(matchers...)
+}
+
+// Constants returns the package level constants of the packages matching all the matchers
+func (archPkg ArchPackage) Constants(matchers ...Matcher[Constant]) ConstantSelection {
+	var constants ConstantSelection
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		constants = append(constants, lo.Filter(pkg.Constants(), func(c internal.Constant, _ int) bool {
+			return lo.EveryBy(matchers, func(matcher Matcher[Constant]) bool {
+				return matcher(c)
+			})
+		})...)
+	})
+	return constants
+}
+
+// ConstantNameMatches matches the constants whose names match the pattern
+func ConstantNameMatches(pattern NamePattern, arg string) Matcher[Constant] {
+	return func(c Constant) bool {
+		return pattern(c.Name(), arg)
+	}
+}
+
+// ShouldBeExported checks all the constants are exported
+func (constants ConstantSelection) ShouldBeExported() error {
+	result := lo.FilterMap(constants, func(c internal.Constant, _ int) (Violation, bool) {
+		return violationAt(c.Position(), c.FullName()), !c.Exported()
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("constants are not exported: %w"), Findings(result).sorted())).Else(nil)
+}
+
+// ShouldResideInPackages checks all the constants are declared in the packages of the paths
+func (constants ConstantSelection) ShouldResideInPackages(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(constants, func(c internal.Constant, _ int) (Violation, bool) {
+		return violationAt(c.Position(), c.FullName()), !lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(c.Package())
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("constants are out of %v: %w"), paths, Findings(result).sorted())).Else(nil)
+}
+
+// NameShould checks the names of all the constants match the pattern
+func (constants ConstantSelection) NameShould(pattern NamePattern, args ...string) error {
+	arg := lo.If(args == nil, "").ElseF(func() string {
+		return args[0]
+	})
+	result := lo.FilterMap(constants, func(c internal.Constant, _ int) (Violation, bool) {
+		return violationAt(c.Position(), c.FullName()), !pattern(c.Name(), arg)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("constant names break the rule: %w"), Findings(result).sorted())).Else(nil)
+}
@@ -0,0 +1,50 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestConstantsOfType(t *testing.T) {
+	constants, err := ConstantsOfType("internal/sample/repository.FF")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"F1", "F2", "F3"}, lo.Map(constants, func(c Constant, _ int) string {
+		return c.Name()
+	}))
+	_, err = ConstantsOfType("internal/sample/repository.Absent")
+	assert.Error(t, err)
+	constants, err = ConstantsOfType("internal/sample/model.User")
+	assert.NoError(t, err)
+	assert.Empty(t, constants)
+	assert.NoError(t, constants.SwitchesShouldBeExhaustive())
+}
+
+func TestConstants_SwitchesShouldBeExhaustive(t *testing.T) {
+	constants, _ := ConstantsOfType("internal/sample/repository.FF")
+	err := constants.SwitchesShouldBeExhaustive()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/repository/constants.go:12:2 misses [F3]")
+	assert.NoError(t, constants.SwitchesShouldBeExhaustive("sample/service/..."))
+	assert.Error(t, constants.SwitchesShouldBeExhaustive("sample/repository"))
+	assert.Error(t, constants.SwitchesShouldBeExhaustive("sample/repository/[a"))
+}
+
+func TestConstantSelection(t *testing.T) {
+	constants := Constants(func(c Constant) bool {
+		return strings.Contains(c.Package(), "internal/sample")
+	})
+	assert.Len(t, constants, 6)
+	err := constants.ShouldBeExported()
+	assert.EqualError(t, err, "constants are not exported: [github.com/kcmvp/archunit/internal/sample/flags.debug]")
+	assert.NoError(t, constants.ShouldResideInPackages("sample/repository", "sample/flags"))
+	err = constants.ShouldResideInPackages("sample/repository")
+	assert.EqualError(t, err, "constants are out of [sample/repository]: [github.com/kcmvp/archunit/internal/sample/flags.debug]")
+	assert.Error(t, constants.ShouldResideInPackages("sample/?"))
+	err = constants.NameShould(BeUpperCase)
+	assert.EqualError(t, err, "constant names break the rule: [github.com/kcmvp/archunit/internal/sample/flags.debug github.com/kcmvp/archunit/internal/sample/repository.Mast github.com/kcmvp/archunit/internal/sample/repository.Slave]")
+	pkgs, _ := Packages("sample/repository")
+	assert.NoError(t, pkgs.Constants(ConstantNameMatches(HavePrefix, "F")).NameShould(BeUpperCase))
+	assert.Len(t, pkgs.Constants(ConstantNameMatches(HavePrefix, "F")), 3)
+}
@@ -0,0 +1,88 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// ConstructorGraphShouldBeAcyclic checks the constructors, functions named New*, of the project do not depend on each
+// other circularly. a constructor depends on the constructors providing the types of its parameters, pointers are
+// resolved to the pointed types and returned errors are ignored, the same as dependency injectors, eg: wire or fx
+func ConstructorGraphShouldBeAcyclic() error {
+	return Project().ConstructorGraphShouldBeAcyclic()
+}
+
+// ConstructorGraphShouldBeAcyclic checks the constructors of the architecture do not depend on each other circularly
+func (arch Architecture) ConstructorGraphShouldBeAcyclic() error {
+	result := constructorCycles(arch.artifact.Packages())
+	return lo.If(len(result) > 0, fmt.Errorf(localize("constructors depend on each other: %w"), Violations(result))).Else(nil)
+}
+
+// constructorCycles returns the cycles of the constructor graph of the packages in the form of "NewA -> NewB -> NewA",
+// every cycle starts from the least constructor so it is reported once
+func constructorCycles(pkgs ArchPackage) []string {
+	providers := map[string][]string{}
+	requires := map[string][]string{}
+	lo.ForEach(pkgs.Functions(), func(f internal.Function, _ int) {
+		if f.Method() || !strings.HasPrefix(f.Name(), "New") {
+			return
+		}
+		sig := f.Raw().Type().(*types.Signature)
+		for i := 0; i < sig.Results().Len(); i++ {
+			if typ := sig.Results().At(i).Type(); typ.String() != "error" {
+				providers[providedType(typ)] = append(providers[providedType(typ)], f.FullName())
+			}
+		}
+		params := make([]string, 0, sig.Params().Len())
+		for i := 0; i < sig.Params().Len(); i++ {
+			params = append(params, providedType(sig.Params().At(i).Type()))
+		}
+		requires[f.FullName()] = params
+	})
+	constructors := lo.Keys(requires)
+	sort.Strings(constructors)
+	var cycles []string
+	var walk func(start, current string, chain []string, visited map[string]bool)
+	walk = func(start, current string, chain []string, visited map[string]bool) {
+		var next []string
+		lo.ForEach(requires[current], func(typ string, _ int) {
+			next = append(next, providers[typ]...)
+		})
+		sort.Strings(next)
+		for _, dep := range lo.Uniq(next) {
+			if dep == start {
+				cycles = append(cycles, strings.Join(append(chain, dep), " -> "))
+			} else if dep > start && !visited[dep] {
+				visited[dep] = true
+				walk(start, dep, append(chain, dep), visited)
+			}
+		}
+	}
+	lo.ForEach(constructors, func(constructor string, _ int) {
+		walk(constructor, constructor, []string{constructor}, map[string]bool{})
+	})
+	return cycles
+}
+
+// providedType returns the name of the type a constructor provides or requires, pointers are resolved to the pointed types
+func providedType(typ types.Type) string {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	return typ.String()
+}
+
+// ConstructorsWithMoreThanNParamsShouldUseConfigStruct checks the constructors, functions named New*, of the selection
+// take at most n parameters, more parameters should be grouped into a config or options struct
+func ConstructorsWithMoreThanNParamsShouldUseConfigStruct(n int, functions Functions) error {
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		params := f.Raw().Type().(*types.Signature).Params().Len()
+		return violationAt(f.Position(), fmt.Sprintf(localize("%s has %d parameters"), f.FullName(), params)), !f.Method() && strings.HasPrefix(f.Name(), "New") &&
+			params > n && !ignoredFunction("ConstructorsWithMoreThanNParamsShouldUseConfigStruct", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("constructors take more than %d parameters, use a config struct: %w"), n, Findings(result).sorted())).Else(nil)
+}
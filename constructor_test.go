@@ -0,0 +1,32 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstructorGraphShouldBeAcyclic(t *testing.T) {
+	assert.NoError(t, ConstructorGraphShouldBeAcyclic())
+	arch, err := Load("testdata/constructor")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("constructor")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"example.com/constructor.NewOrderService -> example.com/constructor.NewUserService -> example.com/constructor.NewOrderService",
+	}, constructorCycles(pkgs))
+}
+
+func TestConstructorsWithMoreThanNParamsShouldUseConfigStruct(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	assert.NoError(t, ConstructorsWithMoreThanNParamsShouldUseConfigStruct(0, pkgs.Functions()))
+	arch, err := Load("testdata/constructor")
+	assert.NoError(t, err)
+	pkgs, err = arch.Packages("constructor")
+	assert.NoError(t, err)
+	assert.NoError(t, ConstructorsWithMoreThanNParamsShouldUseConfigStruct(2, pkgs.Functions()))
+	err = ConstructorsWithMoreThanNParamsShouldUseConfigStruct(1, pkgs.Functions())
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"example.com/constructor.NewUserService has 2 parameters"}, violations)
+}
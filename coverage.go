@@ -0,0 +1,54 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"golang.org/x/tools/cover"
+	"path"
+)
+
+// CoverProfile is the statement coverage of packages, key is the package id and value is a tuple of
+// covered statements and total statements
+type CoverProfile map[string]lo.Tuple2[int, int]
+
+// LoadCoverProfile loads the coverage profile generated by `go test -coverprofile`
+func LoadCoverProfile(file string) (CoverProfile, error) {
+	profiles, err := cover.ParseProfiles(file)
+	if err != nil {
+		return nil, err
+	}
+	coverProfile := CoverProfile{}
+	for _, profile := range profiles {
+		pkg := path.Dir(profile.FileName)
+		stat := coverProfile[pkg]
+		for _, block := range profile.Blocks {
+			stat.B += block.NumStmt
+			if block.Count > 0 {
+				stat.A += block.NumStmt
+			}
+		}
+		coverProfile[pkg] = stat
+	}
+	return coverProfile, nil
+}
+
+// Coverage returns the statement coverage percentage of the package, a package which is absent
+// from the profile is treated as not covered at all
+func (profile CoverProfile) Coverage(pkg string) float64 {
+	stat, ok := profile[pkg]
+	if !ok {
+		return 0
+	}
+	return lo.If(stat.B == 0, 100.0).Else(float64(stat.A) * 100 / float64(stat.B))
+}
+
+// PackagesShouldHaveCoverageAtLeast checks the statement coverage of each package is not less than pct(0~100),
+// different thresholds can be applied to different selections, eg domain 90 and adapters 60
+func (profile CoverProfile) PackagesShouldHaveCoverageAtLeast(pct float64, pkgs ArchPackage) error {
+	result := lo.FilterMap(pkgs, func(pkg *internal.Package, _ int) (string, bool) {
+		coverage := profile.Coverage(pkg.ID())
+		return fmt.Sprintf("%s(%.1f%%)", pkg.ID(), coverage), coverage < pct
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("coverage of packages %w is less than %.1f%%"), Violations(result), pct)).Else(nil)
+}
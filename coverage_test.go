@@ -0,0 +1,33 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadCoverProfile(t *testing.T) {
+	profile, err := LoadCoverProfile("testdata/cover.out")
+	assert.NoError(t, err)
+	assert.Equal(t, 75.0, profile.Coverage("github.com/kcmvp/archunit/internal/sample/service"))
+	assert.Equal(t, 100.0, profile.Coverage("github.com/kcmvp/archunit/internal/sample/repository"))
+	assert.InDelta(t, 33.3, profile.Coverage("github.com/kcmvp/archunit/internal/sample/controller"), 0.1)
+	assert.Equal(t, 0.0, profile.Coverage("github.com/kcmvp/archunit/internal/sample/model"))
+	_, err = LoadCoverProfile("testdata/absent.out")
+	assert.Error(t, err)
+}
+
+func TestPackagesShouldHaveCoverageAtLeast(t *testing.T) {
+	profile, _ := LoadCoverProfile("testdata/cover.out")
+	repository, _ := Packages("sample/repository")
+	service, _ := Packages("sample/service")
+	controller, _ := Packages("sample/controller")
+	assert.NoError(t, profile.PackagesShouldHaveCoverageAtLeast(90, repository))
+	assert.NoError(t, profile.PackagesShouldHaveCoverageAtLeast(60, service))
+	assert.Error(t, profile.PackagesShouldHaveCoverageAtLeast(90, service))
+	err := profile.PackagesShouldHaveCoverageAtLeast(30, append(service, controller...))
+	assert.NoError(t, err)
+	err = profile.PackagesShouldHaveCoverageAtLeast(60, append(service, controller...))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/controller(33.3%)")
+	assert.NotContains(t, err.Error(), "sample/service(")
+}
@@ -0,0 +1,94 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/token"
+	"golang.org/x/tools/go/packages"
+	"regexp"
+	"sync"
+)
+
+// CustomObject is a domain specific artifact discovered in the source code, eg: cron jobs, cli commands or migrations
+type CustomObject struct {
+	Name     string
+	Package  string
+	Position token.Position
+}
+
+// CustomExtractor discovers the custom objects of the package
+type CustomExtractor func(pkg *packages.Package) []CustomObject
+
+var (
+	extractorMu      sync.RWMutex
+	customExtractors = map[string]CustomExtractor{}
+)
+
+// RegisterExtractor registers the extractor of the custom objects by name, registering the same name again replaces
+// the extractor. the custom objects are selected by Custom and governed by the rules of CustomObjects
+func RegisterExtractor(name string, extractor CustomExtractor) {
+	extractorMu.Lock()
+	defer extractorMu.Unlock()
+	customExtractors[name] = extractor
+}
+
+// CustomObjects is the selection of the custom objects
+type CustomObjects []CustomObject
+
+// Custom returns the custom objects of the project discovered by the extractor registered with the name, the objects
+// are filtered by the regular expressions of the matchers on their names when any matcher is specified
+func Custom(name string, matchers ...string) (CustomObjects, error) {
+	return Project().Custom(name, matchers...)
+}
+
+// Custom returns the objects of the architecture extracted by the registered extractor, see the top level one
+func (arch Architecture) Custom(name string, matchers ...string) (CustomObjects, error) {
+	extractorMu.RLock()
+	extractor, ok := customExtractors[name]
+	extractorMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf(localize("can not find extractor %s"), name)
+	}
+	var regs []*regexp.Regexp
+	for _, matcher := range matchers {
+		reg, err := regexp.Compile(matcher)
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, reg)
+	}
+	var objects CustomObjects
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		objects = append(objects, lo.Filter(extractor(pkg.Raw()), func(object CustomObject, _ int) bool {
+			return len(regs) == 0 || lo.SomeBy(regs, func(reg *regexp.Regexp) bool {
+				return reg.MatchString(object.Name)
+			})
+		})...)
+	})
+	return objects, nil
+}
+
+// NameShould checks the names of the custom objects match the pattern
+func (objects CustomObjects) NameShould(pattern NamePattern, args ...string) error {
+	result := lo.FilterMap(objects, func(object CustomObject, _ int) (string, bool) {
+		return fmt.Sprintf("%s %s", object.Position, object.Name), !pattern(object.Name, lo.If(args == nil, "").ElseF(func() string {
+			return args[0]
+		}))
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("custom objects fail to pass naming checking: %w"), Violations(result))).Else(nil)
+}
+
+// ShouldBeInPackages checks the custom objects are declared in the packages of the paths
+func (objects CustomObjects) ShouldBeInPackages(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(objects, func(object CustomObject, _ int) (string, bool) {
+		return fmt.Sprintf("%s %s", object.Position, object.Name), lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(object.Package)
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("custom objects are out of %v: %w"), paths, Violations(result))).Else(nil)
+}
@@ -0,0 +1,45 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"golang.org/x/tools/go/packages"
+	"strings"
+	"testing"
+)
+
+func handlers(pkg *packages.Package) []CustomObject {
+	var objects []CustomObject
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv == nil && strings.HasSuffix(fd.Name.Name, "Handler") {
+				objects = append(objects, CustomObject{Name: fd.Name.Name, Package: pkg.ID, Position: pkg.Fset.Position(fd.Pos())})
+			}
+		}
+	}
+	return objects
+}
+
+func TestCustom(t *testing.T) {
+	_, err := Custom("handlers")
+	assert.Error(t, err)
+	RegisterExtractor("handlers", handlers)
+	objects, err := Custom("handlers")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "LoginHandler", objects[0].Name)
+	assert.Equal(t, "github.com/kcmvp/archunit/internal/sample/controller", objects[0].Package)
+	assert.NoError(t, objects.NameShould(HaveSuffix, "Handler"))
+	assert.Error(t, objects.NameShould(HavePrefix, "Handle"))
+	assert.NoError(t, objects.ShouldBeInPackages("sample/controller/..."))
+	err = objects.ShouldBeInPackages("sample/service")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.True(t, strings.HasSuffix(violations[0], "login_controller.go:51:1 LoginHandler"))
+	assert.Error(t, objects.ShouldBeInPackages("sample/[a"))
+	objects, err = Custom("handlers", "^Logout")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+	_, err = Custom("handlers", "[a")
+	assert.Error(t, err)
+}
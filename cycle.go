@@ -0,0 +1,132 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+// ShouldBeFreeOfCycles checks the packages do not depend on each other circularly, every elementary cycle is reported
+// with its full path, eg: a -> b -> c -> a. the go
+// compiler rejects the import cycles of single packages, so the sub packages out of the selection are counted as
+// their closest package of the selection, eg: Packages("sample/model", "sample/service") detects the cycle of
+// sample/model/dto importing sample/service while sample/service imports sample/model
+func (archPkg ArchPackage) ShouldBeFreeOfCycles() error {
+	graph := archPkg.importGraph()
+	var result []string
+	lo.ForEach(stronglyConnected(graph), func(component []string, _ int) {
+		lo.ForEach(elementaryCycles(graph, component), func(cycle []string, _ int) {
+			result = append(result, strings.Join(append(cycle, cycle[0]), " -> "))
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages depend on each other circularly: %w"), Violations(result))).Else(nil)
+}
+
+// importGraph returns the imports between the packages of the selection, the imports of the sub packages out of the
+// selection are attributed to their closest package of the selection
+func (archPkg ArchPackage) importGraph() map[string][]string {
+	owner := func(id string) (string, bool) {
+		owners := lo.Filter(archPkg.ID(), func(pkg string, _ int) bool {
+			return id == pkg || strings.HasPrefix(id, pkg+"/")
+		})
+		return lo.MaxBy(owners, func(a, b string) bool {
+			return len(a) > len(b)
+		}), len(owners) > 0
+	}
+	graph := map[string][]string{}
+	lo.ForEach(archPkg.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		from, ok := owner(pkg.ID())
+		if !ok {
+			return
+		}
+		lo.ForEach(pkg.Imports(), func(imported string, _ int) {
+			if to, ok := owner(imported); ok && to != from && !lo.Contains(graph[from], to) {
+				graph[from] = append(graph[from], to)
+			}
+		})
+	})
+	return graph
+}
+
+// stronglyConnected returns the strongly connected components with more than one node of the graph by Tarjan's
+// algorithm, the nodes of every component are sorted
+func stronglyConnected(graph map[string][]string) [][]string {
+	nodes := lo.Keys(graph)
+	sort.Strings(nodes)
+	index, low := map[string]int{}, map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var components [][]string
+	var connect func(node string)
+	connect = func(node string) {
+		index[node], low[node] = len(index), len(index)
+		stack = append(stack, node)
+		onStack[node] = true
+		next := append([]string{}, graph[node]...)
+		sort.Strings(next)
+		for _, dep := range next {
+			if _, ok := index[dep]; !ok {
+				connect(dep)
+				low[node] = min(low[node], low[dep])
+			} else if onStack[dep] {
+				low[node] = min(low[node], index[dep])
+			}
+		}
+		if low[node] != index[node] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	lo.ForEach(nodes, func(node string, _ int) {
+		if _, ok := index[node]; !ok {
+			connect(node)
+		}
+	})
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// elementaryCycles returns the cycles of the component in which no node repeats, every cycle starts from its least
+// node and follows the edges of the graph, so a cycle is reported exactly once
+func elementaryCycles(graph map[string][]string, component []string) [][]string {
+	var cycles [][]string
+	lo.ForEach(component, func(start string, _ int) {
+		onPath := map[string]bool{}
+		var path []string
+		var walk func(node string)
+		walk = func(node string) {
+			onPath[node] = true
+			path = append(path, node)
+			next := append([]string{}, graph[node]...)
+			sort.Strings(next)
+			for _, dep := range next {
+				if dep == start {
+					cycles = append(cycles, append([]string{}, path...))
+				} else if dep > start && !onPath[dep] && lo.Contains(component, dep) {
+					walk(dep)
+				}
+			}
+			path = path[:len(path)-1]
+			onPath[node] = false
+		}
+		walk(start)
+	})
+	return cycles
+}
@@ -0,0 +1,60 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldBeFreeOfCycles(t *testing.T) {
+	assert.NoError(t, AllPackages().ShouldBeFreeOfCycles())
+	arch, err := Load("testdata/cycle")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("cycle/...")
+	assert.NoError(t, err)
+	assert.NoError(t, pkgs.ShouldBeFreeOfCycles())
+	pkgs, err = arch.Packages("cycle/model", "cycle/service")
+	assert.NoError(t, err)
+	err = pkgs.ShouldBeFreeOfCycles()
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"example.com/cycle/model -> example.com/cycle/service -> example.com/cycle/model"}, violations)
+	// two cycles share the service
+	arch, err = Load("testdata/cycles")
+	assert.NoError(t, err)
+	pkgs, err = arch.Packages("cycles/model", "cycles/repository", "cycles/service")
+	assert.NoError(t, err)
+	assert.ErrorAs(t, pkgs.ShouldBeFreeOfCycles(), &violations)
+	assert.Equal(t, Violations{
+		"example.com/cycles/model -> example.com/cycles/service -> example.com/cycles/model",
+		"example.com/cycles/repository -> example.com/cycles/service -> example.com/cycles/repository",
+	}, violations)
+}
+
+func TestStronglyConnected(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a", "d"},
+		"d": {"e"},
+		"e": {"d"},
+		"f": {"a"},
+	}
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e"}}, stronglyConnected(graph))
+	assert.Empty(t, stronglyConnected(map[string][]string{"a": {"b"}, "b": nil}))
+}
+
+func TestElementaryCycles(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"a"},
+		"c": {"a"},
+	}
+	assert.Equal(t, [][]string{{"a", "b", "c"}}, stronglyConnected(graph))
+	assert.Equal(t, [][]string{{"a", "b"}, {"a", "c"}}, elementaryCycles(graph, []string{"a", "b", "c"}))
+	graph = map[string][]string{
+		"a": {"b"},
+		"b": {"c", "a"},
+		"c": {"a", "b"},
+	}
+	assert.Equal(t, [][]string{{"a", "b"}, {"a", "b", "c"}, {"b", "c"}}, elementaryCycles(graph, []string{"a", "b", "c"}))
+}
@@ -0,0 +1,28 @@
+package archunit
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// declaration is a declaration of the packages which lives in a file, eg: Type, Function or Constant
+type declaration interface {
+	GoFile() string
+}
+
+// DeclaredInFile matches the declarations in the files whose names match the regular expression, eg:
+// Structs(DeclaredInFile[Type](`_helpers\.go$`)) selects the structs declared in the helper files
+func DeclaredInFile[T declaration](pattern string) Matcher[T] {
+	reg, err := regexp.Compile(pattern)
+	return func(item T) bool {
+		return err == nil && reg.MatchString(filepath.Base(item.GoFile()))
+	}
+}
+
+// DeclaredInTestFile matches the declarations in the _test.go files, eg: the test helpers of TestFunctions
+func DeclaredInTestFile[T declaration]() Matcher[T] {
+	return func(item T) bool {
+		return strings.HasSuffix(item.GoFile(), "_test.go")
+	}
+}
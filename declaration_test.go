@@ -0,0 +1,35 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeclaredInFile(t *testing.T) {
+	service, _ := Packages("sample/service")
+	structs := service.Structs(DeclaredInFile[Type](`_ext\.go$`))
+	assert.Len(t, structs, 1)
+	assert.Equal(t, "github.com/kcmvp/archunit/internal/sample/service.FullNameImpl", structs[0].Name())
+	assert.Empty(t, service.Types().Matching(DeclaredInFile[Type](`[`)))
+	functions := service.Functions().Matching(DeclaredInFile[Function](`^user_service\.go$`))
+	assert.NotEmpty(t, functions)
+	assert.Len(t, functions, len(service.Functions()))
+	repository, _ := Packages("sample/repository")
+	constants := repository.Constants(DeclaredInFile[Constant](`^constants\.go$`))
+	assert.NotEmpty(t, constants)
+	assert.True(t, lo.EveryBy(constants, func(c Constant) bool {
+		return filepath.Base(c.GoFile()) == "constants.go"
+	}))
+	assert.Less(t, len(constants), len(repository.Constants()))
+}
+
+func TestDeclaredInTestFile(t *testing.T) {
+	service, _ := Packages("sample/service")
+	assert.Empty(t, service.Functions().Matching(DeclaredInTestFile[Function]()))
+	tests := service.TestFunctions()
+	assert.NotEmpty(t, tests)
+	assert.Equal(t, tests, tests.Matching(DeclaredInTestFile[Function]()))
+	assert.Empty(t, service.Types().Matching(DeclaredInTestFile[Type]()))
+}
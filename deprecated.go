@@ -0,0 +1,56 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"sort"
+)
+
+// DeprecatedPackages is the deprecated or legacy packages checked by ShouldNotImport by default, the packages have
+// replacements in the standard library or in the maintained modules, eg: io/ioutil is replaced by io and os
+var DeprecatedPackages = []string{
+	"io/ioutil",
+	"crypto/dsa",
+	"golang.org/x/net/context",
+	"golang.org/x/crypto/ssh/terminal",
+	"golang.org/x/crypto/openpgp",
+	"golang.org/x/crypto/md4",
+	"golang.org/x/crypto/ripemd160",
+	"golang.org/x/exp/slices",
+	"golang.org/x/exp/maps",
+	"github.com/golang/protobuf",
+}
+
+// ShouldNotImport returns the rule checking the packages of the module do not import the packages, the
+// DeprecatedPackages when no package is specified. the rule is named after the specified packages and is of
+// CategoryDependency, eg:
+// arch.Validate(ShouldNotImport()) or arch.Validate(ShouldNotImport(append(DeprecatedPackages, "log")...))
+func ShouldNotImport(imports ...string) Rule {
+	name := lo.If(len(imports) > 0, fmt.Sprintf("packages should not import %v", imports)).
+		Else("packages should not import deprecated packages")
+	return NewRule(name, func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotImport(imports...)
+	}, "...").WithCategory(CategoryDependency)
+}
+
+// ShouldNotImport checks the packages do not import the packages or their sub packages, the DeprecatedPackages when
+// no package is specified
+func (archPkg ArchPackage) ShouldNotImport(imports ...string) error {
+	imports = lo.If(len(imports) > 0, imports).Else(DeprecatedPackages)
+	var result []string
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.Imports(), func(path string, _ int) {
+			if inModules(path, imports) {
+				result = append(result, fmt.Sprintf(localize("%s imports %s"), pkg.ID(), path))
+			}
+		})
+	})
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages import the forbidden packages %w"), Violations(result))).Else(nil)
+}
+
+// ShouldNotImport checks the layer the same as ArchPackage.ShouldNotImport
+func (layer ArchLayer) ShouldNotImport(imports ...string) error {
+	return ArchPackage(layer).ShouldNotImport(imports...)
+}
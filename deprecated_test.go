@@ -0,0 +1,21 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldNotImport(t *testing.T) {
+	arch, err := Load("testdata/deprecated")
+	assert.NoError(t, err)
+	results := arch.results(ShouldNotImport())
+	assert.Equal(t, CategoryDependency, results[0].Category)
+	assert.EqualError(t, results[0].Err, "packages should not import deprecated packages: packages import the forbidden packages [example.com/deprecated/legacy imports crypto/dsa example.com/deprecated/legacy imports io/ioutil]")
+	assert.EqualError(t, arch.Validate(ShouldNotImport("log", "crypto")), "packages should not import [log crypto]: packages import the forbidden packages [example.com/deprecated/legacy imports crypto/dsa example.com/deprecated/modern imports log]")
+	assert.NoError(t, arch.Validate(ShouldNotImport("net", "iox")))
+	modern, _ := arch.Packages("deprecated/modern")
+	assert.NoError(t, modern.ShouldNotImport())
+	layer, _ := Layer("sample/...")
+	assert.NoError(t, layer.ShouldNotImport())
+	assert.Error(t, layer.ShouldNotImport("context"))
+}
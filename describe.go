@@ -0,0 +1,73 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DescribedDependencies is the number of the most imported packages listed by Describe
+var DescribedDependencies = 10
+
+// Describe writes the markdown overview of the architecture for the team: the layers and their packages, the most
+// imported packages and the rules with their rationales. the rules of the layers, see WhereLayer, are described
+// along with the rules, eg: arch.Describe(w, Layers().Layer("service", "sample/service/..."), rules...)
+func (arch Architecture) Describe(w io.Writer, layers LayeredArchitecture, rules ...Rule) error {
+	graph := arch.DependencyGraph(layers)
+	pkgs := arch.artifact.Packages()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Architecture of %s\n\n", graph.Module))
+	sb.WriteString(fmt.Sprintf("%d packages, %d types, %d functions\n", len(pkgs),
+		lo.SumBy(pkgs, func(pkg *internal.Package) int {
+			return len(pkg.Types())
+		}),
+		lo.SumBy(pkgs, func(pkg *internal.Package) int {
+			return len(pkg.Functions())
+		})))
+	if names := lo.Keys(graph.Layers); len(names) > 0 {
+		sort.Strings(names)
+		sb.WriteString("\n## Layers\n\n| Layer | Packages |\n| --- | --- |\n")
+		lo.ForEach(names, func(name string, _ int) {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", cell(name), cell(strings.Join(lo.Map(graph.Layers[name], func(pkg string, _ int) string {
+				return graph.label(pkg)
+			}), ", "))))
+		})
+	}
+	importers := map[string]int{}
+	lo.ForEach(lo.Flatten(lo.Values(graph.Imports)), func(pkg string, _ int) {
+		importers[pkg]++
+	})
+	if len(importers) > 0 {
+		top := lo.Keys(importers)
+		sort.Slice(top, func(i, j int) bool {
+			return importers[top[i]] > importers[top[j]] || importers[top[i]] == importers[top[j]] && top[i] < top[j]
+		})
+		if len(top) > DescribedDependencies {
+			top = top[:DescribedDependencies]
+		}
+		sb.WriteString("\n## Top dependencies\n\n| Package | Imported by |\n| --- | --- |\n")
+		lo.ForEach(top, func(pkg string, _ int) {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", cell(graph.label(pkg)), importers[pkg]))
+		})
+	}
+	if rules = append(rules, layers.Rules()...); len(rules) > 0 {
+		sb.WriteString("\n## Rules\n\n| Rule | Category | Packages | Rationale |\n| --- | --- | --- | --- |\n")
+		lo.ForEach(rules, func(rule Rule, _ int) {
+			selected, err := arch.Packages(rule.paths...)
+			count := lo.If(err != nil, "invalid paths").ElseF(func() string {
+				return fmt.Sprintf("%d", len(selected))
+			})
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", cell(rule.name), cell(rule.category), count, cell(rule.rationale)))
+		})
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// cell escapes the text of a markdown table cell
+func cell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+}
@@ -0,0 +1,49 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestArchitecture_Describe(t *testing.T) {
+	arch, err := Load("testdata/cycle")
+	assert.NoError(t, err)
+	layers := Layers().Layer("model", "model/...").Layer("service", "service").WhereLayer("service").MayNotBeAccessedByAnyLayer()
+	rules := []Rule{
+		NewRule("models | dtos are free of cycles", func(pkgs ArchPackage) error {
+			return pkgs.ShouldBeFreeOfCycles()
+		}, "model/...", "service").WithCategory("package").Because("cycles couple the releases"),
+		NewRule("invalid", func(pkgs ArchPackage) error {
+			return nil
+		}, "model/?"),
+	}
+	var sb strings.Builder
+	assert.NoError(t, arch.Describe(&sb, layers, rules...))
+	assert.Equal(t, `# Architecture of example.com/cycle
+
+3 packages, 2 types, 2 functions
+
+## Layers
+
+| Layer | Packages |
+| --- | --- |
+| model | model, model/dto |
+| service | service |
+
+## Top dependencies
+
+| Package | Imported by |
+| --- | --- |
+| model | 1 |
+| service | 1 |
+
+## Rules
+
+| Rule | Category | Packages | Rationale |
+| --- | --- | --- | --- |
+| models \| dtos are free of cycles | package | 3 | cycles couple the releases |
+| invalid |  | invalid paths |  |
+| layer service may not be accessed by any layer |  | 1 |  |
+`, sb.String())
+}
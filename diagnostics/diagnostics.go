@@ -0,0 +1,193 @@
+// Package diagnostics publishes the violations of the architecture rules as LSP publishDiagnostics notifications,
+// so editor plugins can show the violations inline while coding
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic is the LSP diagnostic of a violation, the code is the name of the rule
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"`
+	Code     string `json:"code"`
+	Source   string `json:"source"`
+	Message  string `json:"message"`
+}
+
+// PublishDiagnosticsParams is the parameters of the LSP textDocument/publishDiagnostics notification
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// LogMessageParams is the parameters of the LSP window/logMessage notification, the type 1 is error
+type LogMessageParams struct {
+	Type    int    `json:"type"`
+	Message string `json:"message"`
+}
+
+// collector is the Reporter keeping the results of the rules for Diagnose
+type collector struct {
+	results []archunit.RuleResult
+}
+
+func (c *collector) Report(_ archunit.Architecture, results []archunit.RuleResult) error {
+	c.results = results
+	return nil
+}
+
+// Diagnose validates the rules and groups the violations by the files they are found in. the violations without
+// source positions are reported on the go.mod of the module
+func Diagnose(arch archunit.Architecture, rules ...archunit.Rule) []PublishDiagnosticsParams {
+	files := map[string][]Diagnostic{}
+	c := &collector{}
+	_ = arch.ValidateWithReport(c, rules...)
+	lo.ForEach(c.results, func(result archunit.RuleResult, _ int) {
+		lo.ForEach(result.Violations, func(violation archunit.Violation, _ int) {
+			file, pos := filepath.Join(arch.RootDir(), "go.mod"), Position{}
+			if violation.File != "" {
+				file = violation.File
+				pos = Position{Line: max(violation.Line-1, 0), Character: max(violation.Column-1, 0)}
+			}
+			files[file] = append(files[file], Diagnostic{
+				Range:    Range{Start: pos, End: pos},
+				Severity: 1,
+				Code:     result.Rule,
+				Source:   "archunit",
+				Message:  violation.Message,
+			})
+		})
+	})
+	params := lo.MapToSlice(files, func(file string, diagnostics []Diagnostic) PublishDiagnosticsParams {
+		return PublishDiagnosticsParams{URI: uri(file), Diagnostics: diagnostics}
+	})
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].URI < params[j].URI
+	})
+	return params
+}
+
+// Serve watches the go files of the module in the directory, validates the rules on every change and writes the
+// diagnostics to w as LSP notifications. the files whose violations are fixed are published with empty diagnostics.
+// the failures of scanning the files or loading the module, which are usual while the files are being edited, eg: a
+// file removed during the scan, are logged as LSP messages and retried on the next tick. it returns when the context
+// is done
+func Serve(ctx context.Context, w io.Writer, dir string, interval time.Duration, rules ...archunit.Rule) error {
+	var last, failure string
+	published := map[string]bool{}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// logs the failure once until it changes or the module is loaded
+	logFailure := func(err error) error {
+		if err.Error() == failure {
+			return nil
+		}
+		failure = err.Error()
+		return notify(w, "window/logMessage", LogMessageParams{Type: 1, Message: failure})
+	}
+	for {
+		if snapshot, err := snapshot(dir); err != nil {
+			if err = logFailure(err); err != nil {
+				return err
+			}
+		} else if snapshot != last {
+			if arch, err := archunit.Load(dir); err != nil {
+				if err = logFailure(err); err != nil {
+					return err
+				}
+			} else {
+				last, failure = snapshot, ""
+				if published, err = publish(w, Diagnose(arch, rules...), published); err != nil {
+					return err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish writes the diagnostics, the files published before without violations now are published with empty
+// diagnostics. it returns the files published with the violations
+func publish(w io.Writer, params []PublishDiagnosticsParams, published map[string]bool) (map[string]bool, error) {
+	current := lo.SliceToMap(params, func(param PublishDiagnosticsParams) (string, bool) {
+		return param.URI, true
+	})
+	for file := range published {
+		if !current[file] {
+			params = append(params, PublishDiagnosticsParams{URI: file, Diagnostics: []Diagnostic{}})
+		}
+	}
+	for _, param := range params {
+		if err := notify(w, "textDocument/publishDiagnostics", param); err != nil {
+			return published, err
+		}
+	}
+	return current, nil
+}
+
+// notify writes the notification with the LSP base protocol header
+func notify(w io.Writer, method string, params any) error {
+	data, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	return err
+}
+
+// snapshot returns the fingerprint of the go files and go.mod in the directory, hidden directories are skipped
+func snapshot(dir string) (string, error) {
+	var builder strings.Builder
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && path != dir && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !entry.IsDir() && (strings.HasSuffix(path, ".go") || entry.Name() == "go.mod") {
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&builder, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	return builder.String(), err
+}
+
+func uri(file string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String()
+}
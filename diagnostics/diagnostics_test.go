@@ -0,0 +1,145 @@
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var ordering = archunit.NewRule("ordering", func(pkgs archunit.ArchPackage) error {
+	return pkgs.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+}, "...")
+
+func TestDiagnose(t *testing.T) {
+	naming := archunit.NewRule("naming", func(pkgs archunit.ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views")
+	params := Diagnose(archunit.Project(), ordering, naming)
+	assert.Len(t, params, 2)
+	assert.True(t, strings.HasSuffix(params[0].URI, "/go.mod"))
+	assert.Equal(t, "naming", params[0].Diagnostics[0].Code)
+	assert.True(t, strings.HasPrefix(params[0].URI, "file:///"))
+	assert.True(t, strings.HasSuffix(params[1].URI, "internal/sample/flags/flags.go"))
+	assert.Equal(t, []Diagnostic{{
+		Range:    Range{Start: Position{Line: 26, Character: 3}, End: Position{Line: 26, Character: 3}},
+		Severity: 1,
+		Code:     "ordering",
+		Source:   "archunit",
+		Message:  params[1].Diagnostics[0].Message,
+	}}, params[1].Diagnostics)
+	assert.Empty(t, Diagnose(archunit.Project()))
+}
+
+type buffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *buffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestServe(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.22\n"), 0o644))
+	source := filepath.Join(dir, "keys.go")
+	assert.NoError(t, os.WriteFile(source, []byte(`package fixture
+
+func Keys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+`), 0o644))
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &buffer{}
+	done := make(chan error)
+	go func() {
+		done <- Serve(ctx, out, dir, 50*time.Millisecond, ordering)
+	}()
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), `"line":5,"character":2`)
+	}, 30*time.Second, 50*time.Millisecond)
+	assert.True(t, strings.HasPrefix(out.String(), "Content-Length: "))
+	assert.Contains(t, out.String(), `"method":"textDocument/publishDiagnostics"`)
+	assert.NoError(t, os.WriteFile(source, []byte(`package fixture
+
+func Keys(m map[string]int) []string {
+	return nil
+}
+`), 0o644))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), `"diagnostics":[]`)
+	}, 30*time.Second, 50*time.Millisecond)
+	// the broken module is logged and loaded again once it is fixed
+	gomod := filepath.Join(dir, "go.mod")
+	assert.NoError(t, os.WriteFile(gomod, []byte("module\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), `"method":"window/logMessage"`)
+	}, 30*time.Second, 50*time.Millisecond)
+	assert.NoError(t, os.WriteFile(source, []byte(`package fixture
+
+func Keys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+`), 0o644))
+	assert.NoError(t, os.WriteFile(gomod, []byte("module fixture\n\ngo 1.22\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return strings.Count(out.String(), `"line":5,"character":2`) >= 2
+	}, 30*time.Second, 50*time.Millisecond)
+	assert.Equal(t, 1, strings.Count(out.String(), `"method":"window/logMessage"`))
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestServe_Missing(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fixture")
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &buffer{}
+	done := make(chan error)
+	go func() {
+		done <- Serve(ctx, out, dir, 50*time.Millisecond, ordering)
+	}()
+	// the failure of scanning the files is logged and retried on the next tick
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), `"method":"window/logMessage"`)
+	}, 30*time.Second, 50*time.Millisecond)
+	assert.NoError(t, os.MkdirAll(dir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "keys.go"), []byte(`package fixture
+
+func Keys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+`), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.22\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), `"line":5,"character":2`)
+	}, 30*time.Second, 50*time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,126 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/types"
+	"regexp"
+	"sort"
+)
+
+// Dependency is the import of package To by package From
+type Dependency struct {
+	From string
+	To   string
+}
+
+func (dependency Dependency) String() string {
+	return fmt.Sprintf("%s -> %s", dependency.From, dependency.To)
+}
+
+// ArchDiff is the difference of two architectures of a module, eg: the main branch and a pull request. the exports
+// are the declarations of the exported objects, so a changed signature is both removed and added
+type ArchDiff struct {
+	AddedPackages       []string
+	RemovedPackages     []string
+	AddedDependencies   []Dependency
+	RemovedDependencies []Dependency
+	AddedExports        []string
+	RemovedExports      []string
+}
+
+// Compare returns the difference of the packages, the dependencies between them and the exported objects from the
+// before architecture to the after one, eg: Compare(base, Project()) with the base loaded by LoadArtifact
+func Compare(before, after Architecture) ArchDiff {
+	diff := ArchDiff{}
+	diff.RemovedPackages, diff.AddedPackages = lo.Difference(packageIDs(before), packageIDs(after))
+	diff.RemovedDependencies, diff.AddedDependencies = lo.Difference(dependencies(before), dependencies(after))
+	diff.RemovedExports, diff.AddedExports = lo.Difference(exports(before), exports(after))
+	return diff
+}
+
+// Empty reports whether the architectures are the same
+func (diff ArchDiff) Empty() bool {
+	return len(diff.AddedPackages)+len(diff.RemovedPackages)+len(diff.AddedDependencies)+
+		len(diff.RemovedDependencies)+len(diff.AddedExports)+len(diff.RemovedExports) == 0
+}
+
+// ShouldNotAddDependenciesBetween checks no new dependency is added between the packages of the two paths in either
+// direction, the existing dependencies are accepted, eg:
+// Compare(base, Project()).ShouldNotAddDependenciesBetween("sample/service/...", "sample/repository/...")
+func (diff ArchDiff) ShouldNotAddDependenciesBetween(pathA, pathB string) error {
+	a, err := ScopePattern(pathA)
+	if err != nil {
+		return err
+	}
+	b, err := ScopePattern(pathB)
+	if err != nil {
+		return err
+	}
+	match := func(patterns []*regexp.Regexp, pkg string) bool {
+		return lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(pkg)
+		})
+	}
+	result := lo.FilterMap(diff.AddedDependencies, func(dependency Dependency, _ int) (string, bool) {
+		return dependency.String(), match(a, dependency.From) && match(b, dependency.To) ||
+			match(b, dependency.From) && match(a, dependency.To)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("new dependencies between %s and %s: %w"), pathA, pathB, Violations(result))).Else(nil)
+}
+
+func packageIDs(arch Architecture) []string {
+	pkgs := lo.Map(arch.artifact.Packages(), func(pkg *internal.Package, _ int) string {
+		return pkg.ID()
+	})
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// dependencies returns the imports between the packages of the module
+func dependencies(arch Architecture) []Dependency {
+	pkgs := packageIDs(arch)
+	var result []Dependency
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(lo.Intersect(pkg.Imports(), pkgs), func(imported string, _ int) {
+			result = append(result, Dependency{From: pkg.ID(), To: imported})
+		})
+	})
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
+// exports returns the declarations of the exported objects and the exported methods of the packages, eg:
+// example.com/model: func NewUser(name string) User
+func exports(arch Architecture) []string {
+	var result []string
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		raw := pkg.Raw().Types
+		if raw == nil {
+			return
+		}
+		qualifier := types.RelativeTo(raw)
+		declare := func(obj types.Object) {
+			result = append(result, fmt.Sprintf("%s: %s", pkg.ID(), types.ObjectString(obj, qualifier)))
+		}
+		for _, name := range raw.Scope().Names() {
+			obj := raw.Scope().Lookup(name)
+			if !obj.Exported() {
+				continue
+			}
+			declare(obj)
+			if named, ok := obj.Type().(*types.Named); ok && !types.IsInterface(named) {
+				for i := 0; i < named.NumMethods(); i++ {
+					if method := named.Method(i); method.Exported() {
+						declare(method)
+					}
+				}
+			}
+		}
+	})
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,38 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	old, err := Load("testdata/evolve/v1")
+	assert.NoError(t, err)
+	current, err := Load("testdata/evolve/v2")
+	assert.NoError(t, err)
+	diff := Compare(old, current)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []string{"example.com/evolve/repository"}, diff.AddedPackages)
+	assert.Equal(t, []string{"example.com/evolve/legacy"}, diff.RemovedPackages)
+	assert.Equal(t, []Dependency{
+		{From: "example.com/evolve/repository", To: "example.com/evolve/model"},
+		{From: "example.com/evolve/service", To: "example.com/evolve/repository"},
+	}, diff.AddedDependencies)
+	assert.Empty(t, diff.RemovedDependencies)
+	assert.Equal(t, []string{
+		"example.com/evolve/model: func NewUser(name string) User",
+		"example.com/evolve/repository: func Load(name string) example.com/evolve/model.User",
+		"example.com/evolve/service: func Find(name string) example.com/evolve/model.User",
+	}, diff.AddedExports)
+	assert.Equal(t, []string{
+		"example.com/evolve/legacy: func Migrate()",
+		"example.com/evolve/model: type Role string",
+		"example.com/evolve/service: func Find() example.com/evolve/model.User",
+	}, diff.RemovedExports)
+	assert.True(t, Compare(old, old).Empty())
+
+	err = diff.ShouldNotAddDependenciesBetween("repository", "service")
+	assert.EqualError(t, err, "new dependencies between repository and service: [example.com/evolve/service -> example.com/evolve/repository]")
+	assert.NoError(t, diff.ShouldNotAddDependenciesBetween("service", "model"))
+	assert.Error(t, diff.ShouldNotAddDependenciesBetween("service", "model/?"))
+}
@@ -0,0 +1,33 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TopLevelPackagesShouldHaveDoc checks the top level packages of the selection, the ones without an ancestor package in
+// the selection, have one of the files in their folders, doc.go or README.md by default.
+// eg: TopLevelPackagesShouldHaveDoc(pkgs) with the packages of internal/... requires a doc for each area of internal
+func TopLevelPackagesShouldHaveDoc(pkgs ArchPackage, fileNames ...string) error {
+	if len(fileNames) == 0 {
+		fileNames = []string{"doc.go", "README.md"}
+	}
+	ids := pkgs.ID()
+	result := lo.FilterMap(pkgs, func(pkg *internal.Package, _ int) (string, bool) {
+		if len(pkg.GoFiles()) == 0 || lo.SomeBy(ids, func(id string) bool {
+			return strings.HasPrefix(pkg.ID(), id+"/")
+		}) {
+			return "", false
+		}
+		dir := filepath.Dir(pkg.GoFiles()[0])
+		return pkg.ID(), lo.NoneBy(fileNames, func(name string) bool {
+			_, err := os.Stat(filepath.Join(dir, name))
+			return err == nil
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages do not have any of %v: %w"), fileNames, Violations(result))).Else(nil)
+}
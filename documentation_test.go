@@ -0,0 +1,22 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTopLevelPackagesShouldHaveDoc(t *testing.T) {
+	pkgs, err := Packages("sample/controller/...", "sample/model")
+	assert.NoError(t, err)
+	err = TopLevelPackagesShouldHaveDoc(pkgs)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.ElementsMatch(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/controller",
+		"github.com/kcmvp/archunit/internal/sample/model",
+	}, violations)
+	err = TopLevelPackagesShouldHaveDoc(pkgs, "router.go")
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"github.com/kcmvp/archunit/internal/sample/model"}, violations)
+	assert.NoError(t, TopLevelPackagesShouldHaveDoc(pkgs, "router.go", "user_model.go"))
+}
@@ -0,0 +1,43 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+)
+
+// ExitCodes maps the failures of the architecture checks to the exit codes of the CLI, so the CI pipelines can branch
+// on the kind of the failure. the failed rules of the categories, see Rule.WithCategory, exit with the codes of their
+// categories, eg: 0 for the warnings, and the failed rules of the other categories exit with Violation. the highest
+// code of the failed rules wins
+type ExitCodes struct {
+	Categories map[string]int
+	Violation  int
+	LoadError  int
+}
+
+// DefaultExitCodes exits with 1 for any failed rule and 3 when the architecture can not be loaded
+var DefaultExitCodes = ExitCodes{Violation: 1, LoadError: 3}
+
+// Code returns the exit code of the results, 0 when all the rules pass
+func (codes ExitCodes) Code(results []RuleResult) int {
+	failed := lo.Filter(results, func(result RuleResult, _ int) bool {
+		return result.Err != nil
+	})
+	return lo.Max(lo.Map(failed, func(result RuleResult, _ int) int {
+		if code, ok := codes.Categories[result.Category]; ok {
+			return code
+		}
+		return codes.Violation
+	}))
+}
+
+// ValidateWithExitCode checks all the rules the same as Validate and returns the exit code of the results, eg:
+//
+//	arch, err := archunit.Load(dir)
+//	if err != nil {
+//		os.Exit(codes.LoadError)
+//	}
+//	code, err := arch.ValidateWithExitCode(codes, rules...)
+func (arch Architecture) ValidateWithExitCode(codes ExitCodes, rules ...Rule) (int, error) {
+	results := arch.results(rules...)
+	return codes.Code(results), arch.join(results)
+}
@@ -0,0 +1,46 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExitCodes_Code(t *testing.T) {
+	codes := ExitCodes{Categories: map[string]int{"naming": 0, "layer": 2}, Violation: 1, LoadError: 3}
+	failed := fmt.Errorf("failed")
+	tests := []struct {
+		name    string
+		results []RuleResult
+		code    int
+	}{
+		{name: "none", code: 0},
+		{name: "passed", results: []RuleResult{{Rule: "a", Category: "layer"}}, code: 0},
+		{name: "warning", results: []RuleResult{{Rule: "a", Category: "naming", Err: failed}}, code: 0},
+		{name: "unmapped", results: []RuleResult{{Rule: "a", Err: failed}, {Rule: "b", Category: "naming", Err: failed}}, code: 1},
+		{name: "highest", results: []RuleResult{{Rule: "a", Err: failed}, {Rule: "b", Category: "layer", Err: failed}}, code: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.code, codes.Code(test.results))
+		})
+	}
+}
+
+func TestArchitecture_ValidateWithExitCode(t *testing.T) {
+	naming := NewRule("naming", func(pkgs ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views").WithCategory("naming")
+	passed := NewRule("passed", func(pkgs ArchPackage) error {
+		return nil
+	}, "sample/views").WithCategory("layer")
+	code, err := Project().ValidateWithExitCode(DefaultExitCodes, naming, passed)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, Project().Validate(naming, passed).Error(), err.Error())
+	code, err = Project().ValidateWithExitCode(ExitCodes{Categories: map[string]int{"naming": 0}, Violation: 1}, naming, passed)
+	assert.Equal(t, 0, code)
+	assert.Error(t, err)
+	code, err = Project().ValidateWithExitCode(DefaultExitCodes, passed)
+	assert.Equal(t, 0, code)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+// OnlyAllowedExternalDependencies checks the packages only import the third-party packages of the allowed modules,
+// eg: github.com/samber/lo or golang.org/x. the imports are resolved to the modules required by go.mod, the standard
+// library and the packages of the module itself are not third-party
+func (archPkg ArchPackage) OnlyAllowedExternalDependencies(modules ...string) error {
+	return archPkg.externalDependencies(func(module string) bool {
+		return !inModules(module, modules)
+	}, "external dependencies are not allowed: %w")
+}
+
+// ForbiddenExternalDependencies checks the packages do not import the third-party packages of the forbidden modules,
+// eg: github.com/pkg/errors. the imports are resolved the same as OnlyAllowedExternalDependencies
+func (archPkg ArchPackage) ForbiddenExternalDependencies(modules ...string) error {
+	return archPkg.externalDependencies(func(module string) bool {
+		return inModules(module, modules)
+	}, "external dependencies are forbidden: %w")
+}
+
+// OnlyAllowedExternalDependencies checks the layer the same as ArchPackage.OnlyAllowedExternalDependencies
+func (layer ArchLayer) OnlyAllowedExternalDependencies(modules ...string) error {
+	return ArchPackage(layer).OnlyAllowedExternalDependencies(modules...)
+}
+
+// ForbiddenExternalDependencies checks the layer the same as ArchPackage.ForbiddenExternalDependencies
+func (layer ArchLayer) ForbiddenExternalDependencies(modules ...string) error {
+	return ArchPackage(layer).ForbiddenExternalDependencies(modules...)
+}
+
+// externalDependencies returns the violations of the third-party imports whose modules are rejected
+func (archPkg ArchPackage) externalDependencies(reject func(module string) bool, format string) error {
+	if len(archPkg) == 0 {
+		return nil
+	}
+	arch := archPkg.architecture()
+	requires, err := arch.artifact.Requires()
+	if err != nil {
+		return fmt.Errorf(localize("can not resolve the modules of %s: %w"), arch.Module(), err)
+	}
+	var result []string
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.Imports(), func(path string, _ int) {
+			if !strings.Contains(strings.Split(path, "/")[0], ".") || inModules(path, []string{arch.Module()}) {
+				return
+			}
+			module := lo.MaxBy(lo.Filter(requires, func(require string, _ int) bool {
+				return inModules(path, []string{require})
+			}), func(a, b string) bool {
+				return len(a) > len(b)
+			})
+			module = lo.If(module == "", path).Else(module)
+			if reject(module) {
+				result = append(result, fmt.Sprintf(localize("%s imports %s of %s"), pkg.ID(), path, module))
+			}
+		})
+	})
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize(format), Violations(result))).Else(nil)
+}
+
+// inModules reports whether the path is one of the modules or packages, or under one of them
+func inModules(path string, modules []string) bool {
+	return lo.SomeBy(modules, func(module string) bool {
+		return path == module || strings.HasPrefix(path, module+"/")
+	})
+}
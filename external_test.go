@@ -0,0 +1,27 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArchPackage_OnlyAllowedExternalDependencies(t *testing.T) {
+	pkgs, err := Packages("archunit/internal")
+	assert.NoError(t, err)
+	err = pkgs.OnlyAllowedExternalDependencies("github.com/samber/lo", "golang.org/x")
+	assert.EqualError(t, err, "external dependencies are not allowed: [github.com/kcmvp/archunit/internal imports github.com/fatih/color of github.com/fatih/color]")
+	assert.NoError(t, pkgs.OnlyAllowedExternalDependencies("github.com/samber/lo", "golang.org/x/tools", "github.com/fatih/color"))
+	sample, _ := Layer("sample/...")
+	assert.NoError(t, sample.OnlyAllowedExternalDependencies())
+	assert.NoError(t, ArchPackage{}.OnlyAllowedExternalDependencies())
+}
+
+func TestArchPackage_ForbiddenExternalDependencies(t *testing.T) {
+	pkgs, err := Packages("archunit/internal")
+	assert.NoError(t, err)
+	err = pkgs.ForbiddenExternalDependencies("golang.org/x/tools")
+	assert.EqualError(t, err, "external dependencies are forbidden: [github.com/kcmvp/archunit/internal imports golang.org/x/tools/go/gcexportdata of golang.org/x/tools github.com/kcmvp/archunit/internal imports golang.org/x/tools/go/packages of golang.org/x/tools github.com/kcmvp/archunit/internal imports golang.org/x/tools/go/types/typeutil of golang.org/x/tools]")
+	assert.NoError(t, pkgs.ForbiddenExternalDependencies("github.com/samber/lo/parallel", "golang.org/x/mod"))
+	layer, _ := Layer("archunit/internal")
+	assert.Error(t, layer.ForbiddenExternalDependencies("github.com/samber"))
+}
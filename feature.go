@@ -0,0 +1,105 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+	"go/version"
+	"strings"
+)
+
+// stdlibSince is the go version introducing the standard library packages added since generics
+var stdlibSince = map[string]string{
+	"go1.20": "crypto/ecdh",
+	"go1.21": "cmp log/slog maps slices",
+	"go1.22": "go/version math/rand/v2",
+	"go1.23": "iter structs unique",
+	"go1.24": "crypto/hkdf crypto/mlkem crypto/pbkdf2 crypto/sha3 weak",
+}
+
+// builtinSince is the go version introducing the builtin functions
+var builtinSince = map[string]string{
+	"min":   "go1.21",
+	"max":   "go1.21",
+	"clear": "go1.21",
+}
+
+// ShouldNotUseLanguageFeaturesBeyond checks the project does not use the language features and the standard library
+// packages newer than the go version, eg: 1.18. it detects generics(go1.18), min, max and clear(go1.21),
+// range over integers(go1.22), range over functions(go1.23) and the standard library packages added since go1.20
+func ShouldNotUseLanguageFeaturesBeyond(goVersion string) error {
+	return Project().ShouldNotUseLanguageFeaturesBeyond(goVersion)
+}
+
+// ShouldNotUseLanguageFeaturesBeyond checks the architecture does not use the features newer than the go version
+func (arch Architecture) ShouldNotUseLanguageFeaturesBeyond(goVersion string) error {
+	result, err := featuresBeyond(arch.artifact.Packages(), goVersion)
+	if err != nil {
+		return err
+	}
+	return lo.If(len(result) > 0, fmt.Errorf(localize("language features beyond %s are used: %w"), goVersion, result.sorted())).Else(nil)
+}
+
+func featuresBeyond(pkgs ArchPackage, goVersion string) (Findings, error) {
+	goVersion = "go" + strings.TrimPrefix(goVersion, "go")
+	if !version.IsValid(goVersion) {
+		return nil, fmt.Errorf(localize("invalid go version %s"), goVersion)
+	}
+	since := map[string]string{}
+	for v, paths := range stdlibSince {
+		for _, path := range strings.Fields(paths) {
+			since[path] = v
+		}
+	}
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		report := func(node ast.Node, feature, v string) {
+			if version.Compare(v, goVersion) > 0 {
+				position := pkg.Raw().Fset.Position(node.Pos())
+				result = append(result, violationAt(position, fmt.Sprintf(localize("%s %s requires %s"), position, feature, v)))
+			}
+		}
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			lo.ForEach(file.Imports, func(spec *ast.ImportSpec, _ int) {
+				path := strings.Trim(spec.Path.Value, `"`)
+				if v, ok := since[path]; ok {
+					report(spec, fmt.Sprintf("package %s", path), v)
+				}
+			})
+			ast.Inspect(file, func(node ast.Node) bool {
+				switch n := node.(type) {
+				case *ast.FuncType:
+					if n.TypeParams != nil {
+						report(n.TypeParams, "type parameters", "go1.18")
+					}
+				case *ast.TypeSpec:
+					if n.TypeParams != nil {
+						report(n.TypeParams, "type parameters", "go1.18")
+					}
+				case *ast.Ident:
+					if _, ok := info.Instances[n]; ok {
+						report(n, fmt.Sprintf("generic %s", n.Name), "go1.18")
+					} else if b, ok := info.Uses[n].(*types.Builtin); ok && builtinSince[b.Name()] != "" {
+						report(n, fmt.Sprintf("builtin %s", b.Name()), builtinSince[b.Name()])
+					}
+				case *ast.RangeStmt:
+					if typ := info.TypeOf(n.X); typ != nil {
+						switch t := typ.Underlying().(type) {
+						case *types.Basic:
+							if t.Info()&types.IsInteger != 0 {
+								report(n, "range over integer", "go1.22")
+							}
+						case *types.Signature:
+							report(n, "range over function", "go1.23")
+						}
+					}
+				}
+				return true
+			})
+		})
+	})
+	return result, nil
+}
@@ -0,0 +1,36 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestShouldNotUseLanguageFeaturesBeyond(t *testing.T) {
+	assert.NoError(t, ShouldNotUseLanguageFeaturesBeyond("1.22"))
+	assert.Error(t, ShouldNotUseLanguageFeaturesBeyond("1.17"))
+	assert.Error(t, ShouldNotUseLanguageFeaturesBeyond("one"))
+	arch, err := Load("testdata/features")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("features")
+	assert.NoError(t, err)
+	result, err := featuresBeyond(pkgs, "go1.23")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	result, err = featuresBeyond(pkgs, "1.20")
+	assert.NoError(t, err)
+	features := []string{
+		"package iter requires go1.23",
+		"package slices requires go1.21",
+		"builtin min requires go1.21",
+		"range over integer requires go1.22",
+		"range over function requires go1.23",
+	}
+	assert.Len(t, result, len(features))
+	for _, feature := range features {
+		assert.True(t, strings.Contains(strings.Join(result.messages(), "\n"), feature), feature)
+	}
+	result, _ = featuresBeyond(pkgs, "1.17")
+	assert.True(t, strings.Contains(strings.Join(result.messages(), "\n"), "type parameters requires go1.18"))
+	assert.True(t, strings.Contains(strings.Join(result.messages(), "\n"), "generic Values requires go1.18"))
+}
@@ -0,0 +1,47 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"strings"
+)
+
+// Field is a field of a struct type of the project
+type Field = internal.Field
+
+// fieldName returns the name of the field qualified by its struct, eg: github.com/kcmvp/sample/model.User.Name
+func fieldName(typ internal.Type, field internal.Field) string {
+	return fmt.Sprintf("%s.%s", typ.Name(), field.Name())
+}
+
+// FieldsShouldNotBeExported checks the fields of the structs, the embedded ones included, are not exported, so the
+// state of the structs is only changed through their methods
+func (types Types) FieldsShouldNotBeExported() error {
+	result := types.fields(func(field internal.Field) (string, bool) {
+		return field.Type(), field.Exported()
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("fields are exported: %w"), result.sorted())).Else(nil)
+}
+
+// ShouldNotHaveFieldOfType checks the structs have no field of the types or the pointers of the types, the types are
+// qualified by the package paths, eg: context.Context or database/sql.DB
+func (types Types) ShouldNotHaveFieldOfType(typNames ...string) error {
+	result := types.fields(func(field internal.Field) (string, bool) {
+		return field.Type(), lo.Contains(typNames, strings.TrimLeft(field.Type(), "*"))
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("fields are of the types %v: %w"), typNames, result.sorted())).Else(nil)
+}
+
+// fields returns the sorted fields of the structs the check reports, every field is followed by the detail of the check
+func (types Types) fields(check func(field internal.Field) (string, bool)) Findings {
+	var result Findings
+	lo.ForEach(types, func(typ internal.Type, _ int) {
+		lo.ForEach(typ.Fields(), func(field internal.Field, _ int) {
+			if detail, ok := check(field); ok {
+				result = append(result, violationAt(typ.Artifact().Position(field.Raw()), fmt.Sprintf("%s %s", fieldName(typ, field), detail)))
+			}
+		})
+	})
+	return result.sorted()
+}
@@ -0,0 +1,47 @@
+package archunit
+
+import (
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestType_Fields(t *testing.T) {
+	typ, ok := internal.Arch().Type("github.com/kcmvp/archunit/internal/sample/controller.AppContext")
+	assert.True(t, ok)
+	fields := typ.Fields()
+	assert.Len(t, fields, 1)
+	assert.Equal(t, "Context", fields[0].Name())
+	assert.Equal(t, "context.Context", fields[0].Type())
+	assert.True(t, fields[0].Embedded())
+	assert.True(t, fields[0].Exported())
+	assert.Empty(t, fields[0].Tag())
+	typ, _ = internal.Arch().Type("github.com/kcmvp/archunit/internal/sample/service.NameService")
+	assert.Nil(t, typ.Fields())
+}
+
+func TestTypes_FieldsShouldNotBeExported(t *testing.T) {
+	pkgs, _ := Packages("sample/model", "sample/service")
+	err := pkgs.Structs().FieldsShouldNotBeExported()
+	assert.EqualError(t, err, "fields are exported: [github.com/kcmvp/archunit/internal/sample/model.User.Id string github.com/kcmvp/archunit/internal/sample/model.User.Name string]")
+	pkgs, _ = Packages("sample/service")
+	assert.NoError(t, pkgs.Structs().FieldsShouldNotBeExported())
+}
+
+func TestTypes_ShouldNotHaveFieldOfType(t *testing.T) {
+	structs := Structs(TypeInPackages("internal/sample/..."))
+	err := structs.ShouldNotHaveFieldOfType("context.Context", "database/sql.DB")
+	assert.EqualError(t, err, "fields are of the types [context.Context database/sql.DB]: [github.com/kcmvp/archunit/internal/sample/controller.AppContext.Context context.Context]")
+	err = structs.ShouldNotHaveFieldOfType("github.com/kcmvp/archunit/internal/sample/repository.UserRepository")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/controller/module1.AppController.UserRepository github.com/kcmvp/archunit/internal/sample/repository.UserRepository",
+		"github.com/kcmvp/archunit/internal/sample/service.UserService.userRepository github.com/kcmvp/archunit/internal/sample/repository.UserRepository",
+		"github.com/kcmvp/archunit/internal/sample/service/thirdparty.S3.UserRepository github.com/kcmvp/archunit/internal/sample/repository.UserRepository",
+	}, violations)
+	assert.True(t, lo.EveryBy(structs, func(typ internal.Type) bool {
+		return typ.Fields() != nil || typ.Raw().Underlying().String() == "struct{}"
+	}))
+}
@@ -0,0 +1,69 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"regexp"
+)
+
+// FeatureFlags is the call sites of the feature flag clients in the project
+type FeatureFlags []internal.CallSite
+
+// FeatureFlagsOf returns all the call sites of the feature flag clients in the project, clients are the package paths of
+// the flag clients, eg: github.com/launchdarkly/go-server-sdk/v7 or internal/flags/...
+func FeatureFlagsOf(clients ...string) (FeatureFlags, error) {
+	return Project().FeatureFlagsOf(clients...)
+}
+
+// FeatureFlagsOf returns the call sites of the feature flag clients in the architecture, see the top level one
+func (arch Architecture) FeatureFlagsOf(clients ...string) (FeatureFlags, error) {
+	patterns, err := ScopePattern(clients...)
+	if err != nil {
+		return nil, err
+	}
+	var sites FeatureFlags
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		sites = append(sites, lo.Filter(pkg.CallSites(), func(site internal.CallSite, _ int) bool {
+			return lo.SomeBy(patterns, func(pattern *regexp.Regexp) bool {
+				return pattern.MatchString(site.Callee().Package())
+			}) && !lo.SomeBy(patterns, func(pattern *regexp.Regexp) bool {
+				return pattern.MatchString(pkg.ID())
+			})
+		})...)
+	})
+	return sites, nil
+}
+
+// ShouldBeCheckedOnlyIn checks the feature flags are only checked in the specified packages
+func (flags FeatureFlags) ShouldBeCheckedOnlyIn(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(flags, func(site internal.CallSite, _ int) (Violation, bool) {
+		if caller, ok := site.Caller(); ok && ignoredFunction("FeatureFlags.ShouldBeCheckedOnlyIn", caller) {
+			return Violation{}, false
+		}
+		return violationAt(site.Position(), callSite(site)), lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(site.Package().ID())
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("feature flags are checked out of %v: %w"), paths, Findings(result).sorted())).Else(nil)
+}
+
+// CallSites returns all the flag call sites in the form of "file:line caller -> callee", which can be used
+// to track the flags to be cleaned up
+func (flags FeatureFlags) CallSites() []string {
+	return lo.Map(flags, func(site internal.CallSite, _ int) string {
+		return callSite(site)
+	})
+}
+
+func callSite(site internal.CallSite) string {
+	caller := "init"
+	if f, ok := site.Caller(); ok {
+		caller = f.FullName()
+	}
+	return fmt.Sprintf("%s %s -> %s", site.Position(), caller, site.Callee().FullName())
+}
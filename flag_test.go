@@ -0,0 +1,30 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	flags, err := FeatureFlagsOf("sample/flags")
+	assert.NoError(t, err)
+	sites := flags.CallSites()
+	assert.Len(t, sites, 2)
+	assert.True(t, lo.SomeBy(sites, func(site string) bool {
+		return strings.Contains(site, "sample/service/user_service.go") &&
+			strings.HasSuffix(site, "sample/service.AuditCall -> github.com/kcmvp/archunit/internal/sample/flags.Enabled")
+	}))
+	assert.True(t, lo.SomeBy(sites, func(site string) bool {
+		return strings.Contains(site, "sample/controller/login_controller.go") &&
+			strings.HasSuffix(site, "sample/controller.LoginHandler -> github.com/kcmvp/archunit/internal/sample/flags.Enabled")
+	}))
+	assert.NoError(t, flags.ShouldBeCheckedOnlyIn("sample/service", "sample/controller"))
+	err = flags.ShouldBeCheckedOnlyIn("sample/service")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "LoginHandler")
+	assert.NotContains(t, err.Error(), "AuditCall")
+	_, err = FeatureFlagsOf("sample/../flags")
+	assert.Error(t, err)
+}
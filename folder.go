@@ -0,0 +1,95 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// FolderContent is the files allowed in the folders of the packages, see PackageFoldersShouldOnlyContain
+type FolderContent struct {
+	arch       Architecture
+	extensions []string
+	overrides  []folderOverride
+}
+
+// folderOverride is the files allowed in the folders of the packages of the paths in addition
+type folderOverride struct {
+	paths      []string
+	extensions []string
+}
+
+// PackageFoldersShouldOnlyContain returns the content rule of the package folders, the files of the folders should
+// be go files or have the extensions, eg: ".md", or the names, eg: "Makefile". the sub folders, eg: testdata, are not
+// checked, so stray files, eg: .tmp, .bak or binaries, committed alongside the go sources are reported
+func PackageFoldersShouldOnlyContain(extensions ...string) FolderContent {
+	return Project().PackageFoldersShouldOnlyContain(extensions...)
+}
+
+// PackageFoldersShouldOnlyContain returns the content rule of the package folders of the architecture, see the top
+// level one
+func (arch Architecture) PackageFoldersShouldOnlyContain(extensions ...string) FolderContent {
+	return FolderContent{arch: arch, extensions: extensions}
+}
+
+// Allow returns the content rule allowing the extensions in the folders of the packages of the path in addition, eg:
+// PackageFoldersShouldOnlyContain(".md").Allow("sample/repository", ".sql")
+func (content FolderContent) Allow(path string, extensions ...string) FolderContent {
+	content.overrides = append(append([]folderOverride{}, content.overrides...), folderOverride{paths: []string{path}, extensions: extensions})
+	return content
+}
+
+// Validate checks the folders of the packages, all the packages of the architecture of the rule when no package is
+// specified
+func (content FolderContent) Validate(pkgs ...ArchPackage) error {
+	selected := lo.Flatten(lo.Map(pkgs, func(pkg ArchPackage, _ int) []*internal.Package {
+		return pkg
+	}))
+	if len(pkgs) == 0 {
+		selected = content.arch.artifact.Packages()
+	}
+	var result []string
+	for _, pkg := range selected {
+		if len(pkg.GoFiles()) == 0 {
+			continue
+		}
+		allowed, err := content.allowed(pkg.ID())
+		if err != nil {
+			return err
+		}
+		dir := filepath.Dir(pkg.GoFiles()[0])
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		lo.ForEach(entries, func(entry os.DirEntry, _ int) {
+			name := entry.Name()
+			if !entry.IsDir() && filepath.Ext(name) != ".go" && !lo.Contains(allowed, filepath.Ext(name)) && !lo.Contains(allowed, name) {
+				result = append(result, filepath.Join(dir, name))
+			}
+		})
+	}
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("files are not allowed in the package folders: %w"), Violations(result))).Else(nil)
+}
+
+// allowed returns the extensions and the names allowed in the folder of the package
+func (content FolderContent) allowed(pkg string) ([]string, error) {
+	allowed := content.extensions
+	for _, override := range content.overrides {
+		patterns, err := ScopePattern(override.paths...)
+		if err != nil {
+			return nil, err
+		}
+		if lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(pkg)
+		}) {
+			allowed = append(append([]string{}, allowed...), override.extensions...)
+		}
+	}
+	return allowed, nil
+}
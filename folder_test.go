@@ -0,0 +1,57 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackageFoldersShouldOnlyContain(t *testing.T) {
+	arch, err := Load("testdata/folders")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("...")
+	assert.NoError(t, err)
+	root := arch.RootDir()
+	tests := []struct {
+		name       string
+		content    FolderContent
+		violations []string
+	}{
+		{
+			name:       "go only",
+			content:    PackageFoldersShouldOnlyContain(),
+			violations: []string{"api/README.md", "api/api.go.bak", "api/server", "store/notes.tmp", "store/schema.sql"},
+		},
+		{
+			name:       "override",
+			content:    PackageFoldersShouldOnlyContain(".md").Allow("store", ".sql"),
+			violations: []string{"api/api.go.bak", "api/server", "store/notes.tmp"},
+		},
+		{
+			name:    "names",
+			content: PackageFoldersShouldOnlyContain(".md", ".bak", "server").Allow("store", ".sql", ".tmp"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.content.Validate(pkgs)
+			if len(test.violations) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var violations Violations
+			assert.ErrorAs(t, err, &violations)
+			expected := Violations{}
+			for _, file := range test.violations {
+				expected = append(expected, filepath.Join(root, file))
+			}
+			assert.Equal(t, expected, violations)
+		})
+	}
+	assert.Error(t, PackageFoldersShouldOnlyContain().Allow("store/?", ".sql").Validate(pkgs))
+	assert.NoError(t, PackageFoldersShouldOnlyContain().Validate(ArchPackage{}))
+	err = arch.PackageFoldersShouldOnlyContain(".md", ".bak", "server").Allow("store", ".sql").Validate()
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{filepath.Join(root, "store/notes.tmp")}, violations)
+}
@@ -0,0 +1,114 @@
+package archunit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBaseline is the baseline file of Freeze, relative to the root of the architecture
+var DefaultBaseline = "archunit-baseline.json"
+
+var baselineMu sync.Mutex
+
+// baselinePosition matches the source positions in the messages, eg: service.go:12:3, which are removed from the
+// baseline so editing the unrelated lines does not make the frozen violations new
+var baselinePosition = regexp.MustCompile(`(\.[A-Za-z0-9]+):\d+(:\d+)?\b`)
+
+// Freeze returns the rule failing only on the violations not recorded in the baseline, so the rules can be adopted
+// by the legacy projects without fixing all the existing violations at once. the violations of the rule are recorded
+// on its first run, and the fixed ones are removed from the baseline so they can not come back. the baseline is a json
+// file of the violations keyed by the rule name, relative to the root of the architecture unless it is absolute,
+// DefaultBaseline by default. a violation is recorded by its enclosing object and its message without the source
+// positions, so the violations are still frozen after the lines are shifted
+func Freeze(rule Rule, baseline ...string) Rule {
+	file := DefaultBaseline
+	if len(baseline) > 0 && baseline[0] != "" {
+		file = baseline[0]
+	}
+	check := rule.check
+	rule.check = func(arch Architecture, pkgs ArchPackage) error {
+		root := arch.RootDir()
+		var current []string
+		var findings []Violation
+		if err := check(arch, pkgs); err != nil {
+			findings, _ = found(err)
+			current = lo.Map(findings, func(violation Violation, i int) string {
+				// paths are relative to the root, so the baseline is the same on every machine
+				findings[i].Message = strings.ReplaceAll(violation.Message, root+string(filepath.Separator), "")
+				key := baselinePosition.ReplaceAllString(findings[i].Message, "$1")
+				if obj, ok := arch.artifact.Enclosing(violation.File, violation.Line); violation.File != "" && ok {
+					key = fmt.Sprintf("%s: %s", qualifiedName(obj), key)
+				}
+				return key
+			})
+		}
+		baselineMu.Lock()
+		defer baselineMu.Unlock()
+		path := lo.Ternary(filepath.IsAbs(file), file, filepath.Join(root, file))
+		frozen, err := readBaseline(path)
+		if err != nil {
+			return err
+		}
+		recorded, ok := frozen[rule.name]
+		if fixed := subtract(recorded, current); !ok || len(fixed) > 0 {
+			frozen[rule.name] = lo.Ternary(ok, subtract(recorded, fixed), append([]string{}, current...))
+			if err = writeBaseline(path, frozen); err != nil {
+				return err
+			}
+		}
+		counts := lo.CountValues(recorded)
+		result := lo.Filter(findings, func(_ Violation, i int) bool {
+			counts[current[i]]--
+			return counts[current[i]] < 0
+		})
+		if ok && len(result) > 0 {
+			return fmt.Errorf(localize("new violations out of %s: %w"), file, Findings(result).sorted())
+		}
+		return nil
+	}
+	return rule
+}
+
+// subtract returns the values of from which are not in values, the values are counted as a multiset so a violation
+// recorded once freezes only one of the same violations
+func subtract(from, values []string) []string {
+	counts := lo.CountValues(values)
+	return lo.Filter(from, func(value string, _ int) bool {
+		counts[value]--
+		return counts[value] < 0
+	})
+}
+
+func readBaseline(path string) (map[string][]string, error) {
+	frozen := map[string][]string{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return frozen, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &frozen); err != nil {
+		return nil, fmt.Errorf(localize("invalid baseline %s: %w"), path, err)
+	}
+	return frozen, nil
+}
+
+func writeBaseline(path string, frozen map[string][]string) error {
+	lo.ForEach(lo.Values(frozen), func(violations []string, _ int) {
+		sort.Strings(violations)
+	})
+	data, err := json.MarshalIndent(frozen, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
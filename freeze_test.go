@@ -0,0 +1,89 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), DefaultBaseline)
+	var violations Violations
+	rule := Freeze(NewRule("names", func(pkgs ArchPackage) error {
+		return fmt.Errorf("packages: %w", violations)
+	}, "sample/..."), baseline)
+	arch := Project()
+	violations = Violations{"a", filepath.Join(arch.RootDir(), "b.go:1:1")}
+	assert.NoError(t, arch.Validate(rule))
+	frozen, err := readBaseline(baseline)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"names": {"a", "b.go"}}, frozen)
+	assert.NoError(t, arch.Validate(rule))
+	violations = Violations{"a", filepath.Join(arch.RootDir(), "b.go:3:1")}
+	assert.NoError(t, arch.Validate(rule))
+	violations = Violations{"a", "c"}
+	err = arch.Validate(rule)
+	var result Violations
+	assert.ErrorAs(t, err, &result)
+	assert.Equal(t, Violations{"c"}, result)
+	frozen, _ = readBaseline(baseline)
+	assert.Equal(t, map[string][]string{"names": {"a"}}, frozen)
+	violations = Violations{"b.go:1:1"}
+	assert.Error(t, arch.Validate(rule))
+	assert.NoError(t, os.WriteFile(baseline, []byte("{"), 0o644))
+	assert.ErrorContains(t, arch.Validate(rule), "invalid baseline")
+}
+
+func TestFreeze_ShiftLines(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "keys.go")
+	keys := `package fixture
+
+func Keys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.22\n"), 0o644))
+	assert.NoError(t, os.WriteFile(source, []byte(keys), 0o644))
+	rule := Freeze(NewRule("ordering", func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+	}, "..."))
+	arch, err := Load(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, arch.Validate(rule))
+	frozen, err := readBaseline(filepath.Join(dir, DefaultBaseline))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"ordering": {"fixture.Keys: Keys builds keys at keys.go"}}, frozen)
+	// the unrelated lines shift the violation
+	assert.NoError(t, os.WriteFile(source, []byte(strings.Replace(keys, "\n\n", "\n\n// Keys returns the keys\n\n", 1)), 0o644))
+	arch, err = Load(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, arch.Validate(rule))
+	after, err := readBaseline(filepath.Join(dir, DefaultBaseline))
+	assert.NoError(t, err)
+	assert.Equal(t, frozen, after)
+}
+
+func TestFreeze_EmptySelection(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.22\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "keys.go"), []byte("package fixture\n"), 0o644))
+	arch, err := Load(dir)
+	assert.NoError(t, err)
+	// the baseline of the rule selecting no packages is still under the root of the architecture it is validated on
+	rule := Freeze(NewRule("empty", func(pkgs ArchPackage) error {
+		assert.Empty(t, pkgs)
+		return fmt.Errorf("packages: %w", Violations{"a"})
+	}, "fixture/missing/..."))
+	assert.NoError(t, arch.Validate(rule))
+	frozen, err := readBaseline(filepath.Join(dir, DefaultBaseline))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"empty": {"a"}}, frozen)
+}
@@ -5,14 +5,33 @@ import (
 	"fmt"
 	"github.com/kcmvp/archunit/internal"
 	"github.com/samber/lo"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"regexp"
+	"strings"
+	"unicode"
 )
 
 type Functions []internal.Function
 
+// Function is a function or method declared in the packages of the architecture
+type Function = internal.Function
+
+// architecture returns the architecture the functions belong to, an empty architecture for an empty selection
+func (functions Functions) architecture() Architecture {
+	if len(functions) > 0 {
+		return Architecture{artifact: functions[0].Artifact()}
+	}
+	return Architecture{artifact: &internal.Artifact{}}
+}
+
 func FunctionsOfType(fTypName string) (Functions, error) {
 	typ, ok := internal.Arch().Type(fTypName)
 	if !ok || !typ.FuncType() {
-		return Functions{}, fmt.Errorf("can not find function type %s", fTypName)
+		return Functions{}, fmt.Errorf(localize("can not find function type %s"), fTypName)
 	}
 	lo.ForEach(lo.Filter(internal.Arch().Packages(), func(pkg *internal.Package, _ int) bool {
 		return lo.Contains(pkg.Imports(), typ.Package())
@@ -32,6 +51,79 @@ func FunctionsOfType(fTypName string) (Functions, error) {
 	return Functions{}, nil
 }
 
+// BenchmarksAndFuzzTestsShouldResideIn checks all the benchmarks and fuzz tests of the project
+// are defined in the specified packages
+func BenchmarksAndFuzzTestsShouldResideIn(paths ...string) error {
+	return Project().BenchmarksAndFuzzTestsShouldResideIn(paths...)
+}
+
+// BenchmarksAndFuzzTestsShouldResideIn checks the benchmarks and fuzz tests of the architecture, see the top level one
+func (arch Architecture) BenchmarksAndFuzzTestsShouldResideIn(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	tests := ArchPackage(arch.artifact.Packages()).TestFunctions()
+	result := lo.FilterMap(append(tests.Benchmarks(), tests.FuzzTests()...), func(f internal.Function, _ int) (Violation, bool) {
+		return violationAt(f.Position(), f.FullName()), lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(strings.TrimSuffix(f.Package(), "_test"))
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("%w are out of packages %v"), Findings(result).sorted(), paths)).Else(nil)
+}
+
+// ExamplesShouldReferenceExistingIdentifiers checks the example functions of the project refer to existing
+// exported identifiers of the package. Example_suffix, ExampleF, ExampleT and ExampleT_M with an optional _suffix
+func ExamplesShouldReferenceExistingIdentifiers() error {
+	return Project().ExamplesShouldReferenceExistingIdentifiers()
+}
+
+// ExamplesShouldReferenceExistingIdentifiers checks the example functions of the architecture, see the top level one
+func (arch Architecture) ExamplesShouldReferenceExistingIdentifiers() error {
+	var result Findings
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.TestFunctions(), func(f internal.Function, _ int) {
+			if f.Example() && !exampleRefersTo(pkg, f.Name()) {
+				result = append(result, violationAt(f.Position(), f.FullName()))
+			}
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("examples %w refer to unknown identifiers"), result.sorted())).Else(nil)
+}
+
+func exampleRefersTo(pkg *internal.Package, example string) bool {
+	parts := strings.Split(strings.TrimPrefix(example, "Example"), "_")
+	if last := parts[len(parts)-1]; len(parts) > 1 && len(last) > 0 && unicode.IsLower([]rune(last)[0]) {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) == 1 && parts[0] == "" {
+		return true
+	}
+	obj := pkg.Raw().Types.Scope().Lookup(parts[0])
+	if obj == nil || !obj.Exported() || len(parts) > 2 {
+		return false
+	}
+	if len(parts) == 2 {
+		member, _, _ := types.LookupFieldOrMethod(obj.Type(), true, obj.Pkg(), parts[1])
+		return member != nil && member.Exported()
+	}
+	return true
+}
+
+// Benchmarks return the benchmark functions, func BenchmarkXxx(*testing.B)
+func (functions Functions) Benchmarks() Functions {
+	return lo.Filter(functions, func(f internal.Function, _ int) bool {
+		return f.Benchmark()
+	})
+}
+
+// FuzzTests return the fuzz test functions, func FuzzXxx(*testing.F)
+func (functions Functions) FuzzTests() Functions {
+	return lo.Filter(functions, func(f internal.Function, _ int) bool {
+		return f.Fuzz()
+	})
+}
+
 func (functions Functions) Exclude(names ...string) Functions {
 	panic("to be implemented")
 }
@@ -52,6 +144,59 @@ func (functions Functions) WithParameter() Functions {
 	panic("to be implemented")
 }
 
+// Matching returns the functions of the selection matching all the matchers
+func (functions Functions) Matching(matchers ...Matcher[Function]) Functions {
+	return lo.Filter(functions, func(f internal.Function, _ int) bool {
+		return lo.EveryBy(matchers, func(matcher Matcher[Function]) bool {
+			return matcher(f)
+		})
+	})
+}
+
+// Callers returns the functions of the project calling any function of the selection
+func (functions Functions) Callers() Functions {
+	var callers Functions
+	if len(functions) == 0 {
+		return callers
+	}
+	lo.ForEach(functions.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			if caller, ok := site.Caller(); ok && functions.contains(site.Callee()) {
+				callers = append(callers, caller)
+			}
+		})
+	})
+	return callers.uniq()
+}
+
+// Callees returns the functions called by any function of the selection, including the ones out of the project
+func (functions Functions) Callees() Functions {
+	var callees Functions
+	if len(functions) == 0 {
+		return callees
+	}
+	lo.ForEach(functions.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			if caller, ok := site.Caller(); ok && functions.contains(caller) {
+				callees = append(callees, site.Callee())
+			}
+		})
+	})
+	return callees.uniq()
+}
+
+func (functions Functions) contains(f internal.Function) bool {
+	return lo.ContainsBy(functions, func(item internal.Function) bool {
+		return item.Raw().Origin() == f.Raw().Origin()
+	})
+}
+
+func (functions Functions) uniq() Functions {
+	return lo.UniqBy(functions, func(f internal.Function) *types.Func {
+		return f.Raw().Origin()
+	})
+}
+
 func (functions Functions) ShouldBeInPackage(pkgPath ...string) error {
 	panic("to be implemented")
 }
@@ -60,14 +205,230 @@ func (functions Functions) ShouldBe(visibility Visible) error {
 	panic("to be implemented")
 }
 
+// LineOfCodeLessThan is the same as ShouldHaveLinesLessThan
 func (functions Functions) LineOfCodeLessThan(n int) error {
-	panic("to be implemented")
+	return functions.ShouldHaveLinesLessThan(n)
 }
 
-func (functions Functions) NameShould(pattern NamePattern) error {
-	panic("to be implemented")
+// ShouldHaveLinesLessThan checks the declarations of the functions span less than n lines, from the func keyword to
+// the closing brace. the functions without source, eg: interface methods, are ignored
+func (functions Functions) ShouldHaveLinesLessThan(n int) error {
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		lines := f.Lines()
+		return violationAt(f.Position(), fmt.Sprintf("%s (%d)", f.FullName(), lines)), lines >= n && !ignoredFunction("ShouldHaveLinesLessThan", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions do not have lines less than %d: %w"), n, Findings(result).sorted())).Else(nil)
+}
+
+// ShouldHaveAtMostParams checks the functions take at most n parameters, the receivers of the methods are not
+// parameters and a variadic parameter counts as one
+func (functions Functions) ShouldHaveAtMostParams(n int) error {
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		params := len(f.Params())
+		return violationAt(f.Position(), fmt.Sprintf("%s (%d)", f.FullName(), params)), params > n && !ignoredFunction("ShouldHaveAtMostParams", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions have more than %d parameters: %w"), n, Findings(result).sorted())).Else(nil)
+}
+
+// ShouldHaveAtMostReturns checks the functions return at most n results
+func (functions Functions) ShouldHaveAtMostReturns(n int) error {
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		returns := len(f.Returns())
+		return violationAt(f.Position(), fmt.Sprintf("%s (%d)", f.FullName(), returns)), returns > n && !ignoredFunction("ShouldHaveAtMostReturns", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions have more than %d results: %w"), n, Findings(result).sorted())).Else(nil)
+}
+
+func (functions Functions) NameShould(pattern NamePattern, args ...string) error {
+	if f, ok := lo.Find(functions, func(f internal.Function) bool {
+		return !pattern(f.Name(), lo.If(args == nil, "").ElseF(func() string {
+			return args[0]
+		}))
+	}); ok {
+		return fmt.Errorf(localize("function %s faild to pass naming checking"), f.FullName())
+	}
+	return nil
 }
 
 func (functions Functions) NoAnonymous() error {
 	panic("to be implemented")
 }
+
+// ExportedFunctionsShouldHaveTests checks that each exported function of the selection is referred by
+// at least one _test.go file of its package, either the internal test or the external test(package xxx_test)
+func ExportedFunctionsShouldHaveTests(functions Functions) error {
+	referred := map[string]map[token.Position]bool{}
+	untested := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		if !f.Exported() || ignoredFunction("ExportedFunctionsShouldHaveTests", f) {
+			return Violation{}, false
+		}
+		if _, ok := referred[f.Package()]; !ok {
+			referred[f.Package()] = referredByTests(f.Artifact().Package(f.Package()))
+		}
+		return violationAt(f.Position(), f.FullName()), !referred[f.Package()][f.Position()]
+	})
+	return lo.If(len(untested) > 0, fmt.Errorf(localize("functions %w are not referred by any test"), Findings(untested).sorted())).Else(nil)
+}
+
+// ExportedFunctionsShouldNotPanic checks the exported functions of the selection do not call panic directly, library
+// layers usually promise to return errors instead. panics in function literals and in the branches guarded by false
+// constants, eg: `if debug { panic(...) }` with debug defined in files behind build tags, are not reachable and ignored
+func ExportedFunctionsShouldNotPanic(functions Functions) error {
+	var result Findings
+	lo.ForEach(functions, func(f internal.Function, _ int) {
+		decl := f.Decl()
+		if !f.Exported() || decl == nil || decl.Body == nil || ignoredFunction("ExportedFunctionsShouldNotPanic", f) {
+			return
+		}
+		pkg := f.Artifact().Package(f.Package())
+		if pkg == nil {
+			return
+		}
+		lo.ForEach(panicCalls(decl.Body, pkg.Raw().TypesInfo), func(call *ast.CallExpr, _ int) {
+			position := pkg.Raw().Fset.Position(call.Pos())
+			result = append(result, violationAt(position, fmt.Sprintf(localize("%s at %s"), f.FullName(), position)))
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("exported functions panic %w"), result.sorted())).Else(nil)
+}
+
+// panicCalls returns the reachable calls of builtin panic in the node
+func panicCalls(node ast.Node, info *types.Info) []*ast.CallExpr {
+	var calls []*ast.CallExpr
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			if cond := info.Types[n.Cond].Value; cond != nil && cond.Kind() == constant.Bool {
+				if n.Init != nil {
+					calls = append(calls, panicCalls(n.Init, info)...)
+				}
+				if constant.BoolVal(cond) {
+					calls = append(calls, panicCalls(n.Body, info)...)
+				} else if n.Else != nil {
+					calls = append(calls, panicCalls(n.Else, info)...)
+				}
+				return false
+			}
+		case *ast.CallExpr:
+			if id, ok := n.Fun.(*ast.Ident); ok {
+				if _, ok := info.Uses[id].(*types.Builtin); ok && id.Name == "panic" {
+					calls = append(calls, n)
+				}
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// ContextShouldBeFirstParam checks the functions of the selection taking a context.Context take it as the first
+// parameter, the receivers of the methods are not parameters
+func ContextShouldBeFirstParam(functions Functions) error {
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		params := f.Raw().Type().(*types.Signature).Params()
+		misplaced := false
+		for i := 1; i < params.Len(); i++ {
+			misplaced = misplaced || params.At(i).Type().String() == "context.Context"
+		}
+		return violationAt(f.Position(), f.FullName()), misplaced && !ignoredFunction("ContextShouldBeFirstParam", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions do not take context as the first parameter %w"), Findings(result).sorted())).Else(nil)
+}
+
+// ErrorShouldBeLastReturn checks the functions of the selection returning an error return it as the last result
+func ErrorShouldBeLastReturn(functions Functions) error {
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		results := f.Raw().Type().(*types.Signature).Results()
+		misplaced := false
+		for i := 0; i < results.Len()-1; i++ {
+			misplaced = misplaced || types.Identical(results.At(i).Type(), types.Universe.Lookup("error").Type())
+		}
+		return violationAt(f.Position(), f.FullName()), misplaced && !ignoredFunction("ErrorShouldBeLastReturn", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("functions do not return error as the last result %w"), Findings(result).sorted())).Else(nil)
+}
+
+// referredByTests returns the declaration positions of the functions referred in the _test.go files of the package.
+// the identifiers are resolved by the type information of the test packages, so the functions and the methods of the
+// same names are told apart. the test packages are type checked apart from the package, so the functions are matched
+// by the positions of their declarations rather than the objects
+func referredByTests(pkg *internal.Package) map[token.Position]bool {
+	referred := map[token.Position]bool{}
+	if pkg == nil {
+		return referred
+	}
+	lo.ForEach(pkg.TestPackages(), func(testPkg *packages.Package, _ int) {
+		for id, obj := range testPkg.TypesInfo.Uses {
+			if f, ok := obj.(*types.Func); ok && strings.HasSuffix(testPkg.Fset.Position(id.Pos()).Filename, "_test.go") {
+				referred[testPkg.Fset.Position(f.Origin().Pos())] = true
+			}
+		}
+	})
+	return referred
+}
+
+// ExportedFunctionsShouldNotExposeChannels checks the parameters and results of the exported functions of the selection
+// do not have channel types, except for the functions in the streaming packages of the paths. boundaries should expose
+// callbacks or iterators instead, channels in the underlying types of named types are not inspected
+func ExportedFunctionsShouldNotExposeChannels(functions Functions, streaming ...string) error {
+	patterns, err := ScopePattern(streaming...)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		sig := f.Raw().Type().(*types.Signature)
+		return violationAt(f.Position(), f.FullName()), f.Exported() && (hasChannel(sig.Params()) || hasChannel(sig.Results())) &&
+			lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+				return pattern.MatchString(f.Package())
+			}) && !ignoredFunction("ExportedFunctionsShouldNotExposeChannels", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("exported functions expose channels %w"), Findings(result).sorted())).Else(nil)
+}
+
+// hasChannel reports whether the type is or is composed of channel types, named types are not expanded
+func hasChannel(typ types.Type) bool {
+	switch t := typ.(type) {
+	case *types.Chan:
+		return true
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if hasChannel(t.At(i).Type()) {
+				return true
+			}
+		}
+	case *types.Pointer:
+		return hasChannel(t.Elem())
+	case *types.Slice:
+		return hasChannel(t.Elem())
+	case *types.Array:
+		return hasChannel(t.Elem())
+	case *types.Map:
+		return hasChannel(t.Key()) || hasChannel(t.Elem())
+	case *types.Signature:
+		return hasChannel(t.Params()) || hasChannel(t.Results())
+	}
+	return false
+}
+
+// ExportedCollectionsShouldReturnIterators checks the exported functions of the selection whose names match the regular
+// expression, eg: ^(List|All), return iter.Seq or iter.Seq2 rather than slices, []byte results are not collections
+func ExportedCollectionsShouldReturnIterators(functions Functions, pattern string) error {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(functions, func(f internal.Function, _ int) (Violation, bool) {
+		results := f.Raw().Type().(*types.Signature).Results()
+		returnsSlice := false
+		for i := 0; i < results.Len(); i++ {
+			if slice, ok := results.At(i).Type().Underlying().(*types.Slice); ok && !types.Identical(slice.Elem(), types.Typ[types.Byte]) {
+				returnsSlice = true
+			}
+		}
+		return violationAt(f.Position(), f.FullName()), f.Exported() && returnsSlice && reg.MatchString(f.Name()) &&
+			!ignoredFunction("ExportedCollectionsShouldReturnIterators", f)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("exported functions return slices instead of iterators %w"), Findings(result).sorted())).Else(nil)
+}
@@ -0,0 +1,209 @@
+package archunit
+
+import (
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestExportedFunctionsShouldHaveTests(t *testing.T) {
+	service, _ := Packages("sample/service")
+	assert.NoError(t, ExportedFunctionsShouldHaveTests(service.Functions()))
+	err := ExportedFunctionsShouldHaveTests(service.Types().Methods())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service.UserService).SearchUsersByFirstName")
+	assert.NotContains(t, err.Error(), "service.UserService).GetUserByNameAndAddress")
+	assert.NotContains(t, err.Error(), "service.UserService).GetUserById")
+	repository, _ := Packages("sample/repository")
+	assert.NoError(t, ExportedFunctionsShouldHaveTests(repository.Types().Methods()))
+	controller, _ := Packages("sample/controller")
+	err = ExportedFunctionsShouldHaveTests(controller.Functions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/controller.LoginHandler")
+}
+
+func TestBenchmarksAndFuzzTests(t *testing.T) {
+	service, _ := Packages("sample/service")
+	tests := service.TestFunctions()
+	assert.Len(t, tests, 5)
+	assert.Len(t, tests.Benchmarks(), 1)
+	assert.Len(t, tests.FuzzTests(), 1)
+	assert.NoError(t, tests.Benchmarks().NameShould(HavePrefix, "BenchmarkAudit"))
+	assert.Error(t, tests.NameShould(HavePrefix, "Benchmark"))
+	assert.NoError(t, BenchmarksAndFuzzTestsShouldResideIn("sample/service"))
+	err := BenchmarksAndFuzzTestsShouldResideIn("sample/repository")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/service.BenchmarkAuditCall")
+	assert.Contains(t, err.Error(), "sample/service.FuzzAuditCall")
+}
+
+func TestExamplesShouldReferenceExistingIdentifiers(t *testing.T) {
+	assert.NoError(t, ExamplesShouldReferenceExistingIdentifiers())
+	arch, err := Load("testdata/examples")
+	assert.NoError(t, err)
+	err = arch.ExamplesShouldReferenceExistingIdentifiers()
+	assert.EqualError(t, err, "examples [example.com/examples.ExampleUnknown] refer to unknown identifiers")
+	findings, _ := found(err)
+	assert.Len(t, findings, 1)
+	assert.True(t, strings.HasSuffix(findings[0].File, "examples/examples_test.go"), findings[0].File)
+	assert.Equal(t, 7, findings[0].Line)
+	service, _ := Packages("sample/service")
+	tests := []struct {
+		example string
+		exists  bool
+	}{
+		{"Example", true},
+		{"Example_basic", true},
+		{"ExampleAuditCall", true},
+		{"ExampleAuditCall_withContext", true},
+		{"ExampleUserService", true},
+		{"ExampleUserService_GetUserById", true},
+		{"ExampleUserService_GetUserById_second", true},
+		{"ExampleAudit", true},
+		{"ExampleAuditLog", false},
+		{"ExampleUserService_GetUser", false},
+		{"ExampleUserService_userRepository", true},
+		{"ExampleNameService_FirstNameI", true},
+		{"ExampleNameService_FirstName", false},
+		{"ExampleUserService_GetUserById_Second", false},
+	}
+	for _, test := range tests {
+		t.Run(test.example, func(t *testing.T) {
+			assert.Equal(t, test.exists, exampleRefersTo(service[0], test.example))
+		})
+	}
+}
+
+func TestExportedFunctionsShouldNotPanic(t *testing.T) {
+	flags, _ := Packages("sample/flags")
+	assert.NoError(t, ExportedFunctionsShouldNotPanic(flags.Functions()))
+	assert.NoError(t, ExportedFunctionsShouldNotPanic(flags.Types().Methods()))
+	service, _ := Packages("sample/service")
+	assert.NoError(t, ExportedFunctionsShouldNotPanic(service.Functions()))
+	err := ExportedFunctionsShouldNotPanic(service.Types().Methods())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "(github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl).FirstNameI at ")
+	assert.Contains(t, err.Error(), "sample/service/user_service.go:")
+	assert.Contains(t, err.Error(), "GetUserById")
+}
+
+func TestFunctions_CallersAndCallees(t *testing.T) {
+	names := func(functions Functions) []string {
+		return lo.Map(functions, func(f internal.Function, _ int) string {
+			return f.FullName()
+		})
+	}
+	flags, _ := Packages("sample/flags")
+	assert.ElementsMatch(t, []string{
+		"(github.com/kcmvp/archunit/internal/sample/flags.Client).Enabled",
+		"sort.Strings",
+	}, names(flags.Functions().Callees()))
+	assert.Equal(t, []string{"github.com/kcmvp/archunit/internal/sample/flags.Enabled"}, names(flags.Types().Methods().Callers()))
+	callers := flags.Functions().Callers()
+	assert.ElementsMatch(t, []string{
+		"github.com/kcmvp/archunit/internal/sample/service.AuditCall",
+		"github.com/kcmvp/archunit/internal/sample/controller.LoginHandler",
+	}, names(callers))
+	assert.Empty(t, callers.Callers())
+	assert.Contains(t, names(callers.Callees()), "github.com/kcmvp/archunit/internal/sample/flags.Enabled")
+}
+
+func TestExportedFunctionsShouldNotExposeChannels(t *testing.T) {
+	controller, _ := Layer("sample/controller")
+	assert.NoError(t, ExportedFunctionsShouldNotExposeChannels(controller.Functions()))
+	err := ExportedFunctionsShouldNotExposeChannels(controller.Types().Methods())
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"(github.com/kcmvp/archunit/internal/sample/controller.AppContext).Done"}, violations)
+	assert.NoError(t, ExportedFunctionsShouldNotExposeChannels(controller.Types().Methods(), "sample/controller"))
+	assert.Error(t, ExportedFunctionsShouldNotExposeChannels(controller.Types().Methods(), "sample/[a"))
+}
+
+func TestExportedCollectionsShouldReturnIterators(t *testing.T) {
+	pkgs, _ := Packages("sample/...")
+	assert.NoError(t, ExportedCollectionsShouldReturnIterators(pkgs.Functions(), "^List"))
+	err := ExportedCollectionsShouldReturnIterators(pkgs.Functions(), "Names$")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.ElementsMatch(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/flags.Names",
+		"github.com/kcmvp/archunit/internal/sample/flags.SortedNames",
+	}, violations)
+	assert.Error(t, ExportedCollectionsShouldReturnIterators(pkgs.Functions(), "[a"))
+}
+
+func TestArchPackage_Closures(t *testing.T) {
+	arch, err := Load("testdata/closures")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("closures")
+	assert.NoError(t, err)
+	closures := pkgs.Closures()
+	assert.Equal(t, []string{"Handler", "parse", "Server.Handle.func1", "NewMiddleware.func1"}, lo.Map(closures, func(f internal.Function, _ int) string {
+		return f.Name()
+	}))
+	assert.True(t, lo.EveryBy(closures, func(f internal.Function) bool {
+		return f.Literal() != nil && strings.HasSuffix(f.GoFile(), "handler.go")
+	}))
+	assert.True(t, lo.NoneBy(pkgs.Functions(), func(f internal.Function) bool {
+		return f.Literal() != nil
+	}))
+}
+
+func TestContextShouldBeFirstParam(t *testing.T) {
+	arch, _ := Load("testdata/closures")
+	pkgs, _ := arch.Packages("closures")
+	err := ContextShouldBeFirstParam(append(pkgs.Functions(), pkgs.Closures()...))
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"example.com/closures.Handler", "example.com/closures.Lookup", "example.com/closures.Server.Handle.func1"}, violations)
+	sample, _ := Packages("sample/controller/...")
+	assert.NoError(t, ContextShouldBeFirstParam(sample.Functions()))
+}
+
+func TestErrorShouldBeLastReturn(t *testing.T) {
+	arch, _ := Load("testdata/closures")
+	pkgs, _ := arch.Packages("closures")
+	err := ErrorShouldBeLastReturn(append(pkgs.Functions(), pkgs.Closures()...))
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"example.com/closures.Lookup", "example.com/closures.parse"}, violations)
+	sample, _ := Packages("sample/...")
+	assert.NoError(t, ErrorShouldBeLastReturn(append(sample.Functions(), sample.Closures()...)))
+}
+
+func TestFunctions_ShouldHaveLinesLessThan(t *testing.T) {
+	arch, _ := Load("testdata/closures")
+	pkgs, _ := arch.Packages("closures")
+	functions := append(pkgs.Functions(), pkgs.Closures()...)
+	err := functions.ShouldHaveLinesLessThan(4)
+	assert.EqualError(t, err, "functions do not have lines less than 4: [example.com/closures.Lookup (4) example.com/closures.NewMiddleware (10) example.com/closures.NewMiddleware.func1 (8)]")
+	assert.NoError(t, functions.ShouldHaveLinesLessThan(11))
+	assert.Equal(t, err, functions.LineOfCodeLessThan(4))
+	assert.NoError(t, pkgs.Types().Methods().ShouldHaveLinesLessThan(4))
+	service, _ := Packages("sample/service")
+	typ, _ := lo.Find(service.Types(), func(typ internal.Type) bool {
+		return typ.Interface()
+	})
+	assert.NoError(t, Functions(typ.Methods()).ShouldHaveLinesLessThan(1))
+}
+
+func TestFunctions_ShouldHaveAtMostParams(t *testing.T) {
+	arch, _ := Load("testdata/closures")
+	pkgs, _ := arch.Packages("closures")
+	functions := append(pkgs.Functions(), pkgs.Closures()...)
+	err := functions.ShouldHaveAtMostParams(1)
+	assert.EqualError(t, err, "functions have more than 1 parameters: [example.com/closures.Handler (2) example.com/closures.Lookup (2) example.com/closures.Server.Handle.func1 (2)]")
+	assert.NoError(t, functions.ShouldHaveAtMostParams(2))
+	assert.NoError(t, pkgs.Types().Methods().ShouldHaveAtMostParams(0))
+}
+
+func TestFunctions_ShouldHaveAtMostReturns(t *testing.T) {
+	arch, _ := Load("testdata/closures")
+	pkgs, _ := arch.Packages("closures")
+	functions := append(pkgs.Functions(), pkgs.Closures()...)
+	err := functions.ShouldHaveAtMostReturns(1)
+	assert.EqualError(t, err, "functions have more than 1 results: [example.com/closures.Lookup (2) example.com/closures.NewMiddleware.func1 (2) example.com/closures.parse (2)]")
+	assert.NoError(t, functions.ShouldHaveAtMostReturns(2))
+}
@@ -0,0 +1,114 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// GoroutinesShouldRecover checks the goroutines started with function literals in the packages of the selection
+// defer a function recovering the panics, either a function literal calling recover or one of the approved helpers,
+// eg: GoroutinesShouldRecover(pkgs, "internal/safego.Recover"). a panic in a goroutine crashes the whole process
+func GoroutinesShouldRecover(pkgs ArchPackage, helpers ...string) error {
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			ast.Inspect(file, func(node ast.Node) bool {
+				stmt, ok := node.(*ast.GoStmt)
+				if !ok {
+					return true
+				}
+				lit, ok := ast.Unparen(stmt.Call.Fun).(*ast.FuncLit)
+				if ok && !lo.SomeBy(lit.Body.List, func(s ast.Stmt) bool {
+					deferred, ok := s.(*ast.DeferStmt)
+					return ok && recovers(deferred.Call, info, helpers...)
+				}) {
+					position := pkg.Raw().Fset.Position(stmt.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf(localize("%s goroutine does not recover"), position)))
+				}
+				return true
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("goroutines do not recover: %w"), result.sorted())).Else(nil)
+}
+
+// recovers reports whether the deferred call recovers the panics, it is a call of the helpers or a function literal
+// calling the builtin recover
+func recovers(call *ast.CallExpr, info *types.Info, helpers ...string) bool {
+	if lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit); ok {
+		found := false
+		ast.Inspect(lit.Body, func(node ast.Node) bool {
+			if c, ok := node.(*ast.CallExpr); ok {
+				if id, ok := ast.Unparen(c.Fun).(*ast.Ident); ok {
+					if b, ok := info.Uses[id].(*types.Builtin); ok && b.Name() == "recover" {
+						found = true
+					}
+				}
+			}
+			return !found
+		})
+		return found
+	}
+	f := calledFunc(call, info)
+	return f != nil && funcMatches(f, helpers...)
+}
+
+// ShouldNotSpawnGoroutinesInLoops checks the packages of the selection do not start goroutines in loops unless the
+// loop acquires a semaphore first, either sending to a channel or calling an Acquire method, eg: semaphore.Weighted.
+// errgroup.Group.Go with SetLimit is not a go statement and is not reported
+func ShouldNotSpawnGoroutinesInLoops(pkgs ArchPackage) error {
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			ast.Inspect(file, func(node ast.Node) bool {
+				stmt, ok := node.(*ast.GoStmt)
+				if !ok {
+					return true
+				}
+				path, _ := astutil.PathEnclosingInterval(file, stmt.Pos(), stmt.End())
+				var loops []*ast.BlockStmt
+			enclosing:
+				for _, n := range path {
+					switch n := n.(type) {
+					case *ast.ForStmt:
+						loops = append(loops, n.Body)
+					case *ast.RangeStmt:
+						loops = append(loops, n.Body)
+					case *ast.FuncLit, *ast.FuncDecl:
+						break enclosing
+					}
+				}
+				if len(loops) > 0 && !lo.SomeBy(loops, acquiresSemaphore) {
+					position := pkg.Raw().Fset.Position(stmt.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf(localize("%s goroutine is spawned in loop"), position)))
+				}
+				return true
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("goroutines are spawned in loops: %w"), result.sorted())).Else(nil)
+}
+
+// acquiresSemaphore reports whether the loop body sends to a channel or calls an Acquire method out of function literals
+func acquiresSemaphore(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SendStmt:
+			found = true
+		case *ast.CallExpr:
+			if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok && (sel.Sel.Name == "Acquire" || sel.Sel.Name == "TryAcquire") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,34 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestGoroutinesShouldRecover(t *testing.T) {
+	arch, err := Load("testdata/goroutine")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("goroutine")
+	assert.NoError(t, err)
+	err = GoroutinesShouldRecover(pkgs, "example.com/goroutine.Recover")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "worker.go:40:2 goroutine does not recover"))
+	err = GoroutinesShouldRecover(pkgs)
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 5)
+}
+
+func TestShouldNotSpawnGoroutinesInLoops(t *testing.T) {
+	arch, err := Load("testdata/goroutine")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("goroutine")
+	assert.NoError(t, err)
+	err = ShouldNotSpawnGoroutinesInLoops(pkgs)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "worker.go:50:3 goroutine is spawned in loop"))
+}
@@ -0,0 +1,94 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DependencyGraph is the import graph of the packages of the module, the packages are clustered by the layers
+type DependencyGraph struct {
+	Module   string
+	Packages []string
+	Imports  map[string][]string
+	Layers   map[string][]string
+}
+
+// DependencyGraph returns the import graph of the packages of the module, the imports out of the module are omitted.
+// the packages are clustered by the layers of the layered architectures, both the named layers of Layer and the
+// ordered layers of Layers, eg: arch.DependencyGraph(Layers().Layer("service", "sample/service/..."))
+func (arch Architecture) DependencyGraph(layers ...LayeredArchitecture) DependencyGraph {
+	graph := DependencyGraph{Module: arch.artifact.Module(), Imports: map[string][]string{}, Layers: map[string][]string{}}
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		graph.Packages = append(graph.Packages, pkg.ID())
+	})
+	sort.Strings(graph.Packages)
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		imports := lo.Intersect(pkg.Imports(), graph.Packages)
+		sort.Strings(imports)
+		if len(imports) > 0 {
+			graph.Imports[pkg.ID()] = imports
+		}
+	})
+	for _, layered := range layers {
+		lo.ForEach(layered.definitions, func(definition layerDefinition, _ int) {
+			pkgs, _ := arch.Packages(definition.paths...)
+			graph.cluster(definition.name, pkgs.ID())
+		})
+		lo.ForEach(layered.layers, func(layer ArchLayer, _ int) {
+			graph.cluster(layer.Name(), layer.packages())
+		})
+	}
+	return graph
+}
+
+// cluster adds the packages to the layer, a package belongs to the first layer it is clustered by
+func (graph DependencyGraph) cluster(layer string, pkgs []string) {
+	clustered := lo.Flatten(lo.Values(graph.Layers))
+	pkgs = lo.Filter(pkgs, func(pkg string, _ int) bool {
+		return lo.Contains(graph.Packages, pkg) && !lo.Contains(clustered, pkg)
+	})
+	sort.Strings(pkgs)
+	if len(pkgs) > 0 {
+		graph.Layers[layer] = append(graph.Layers[layer], pkgs...)
+	}
+}
+
+// label returns the package path relative to the module
+func (graph DependencyGraph) label(pkg string) string {
+	return lo.If(pkg == graph.Module, pkg).ElseF(func() string {
+		return strings.TrimPrefix(pkg, graph.Module+"/")
+	})
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz, every layer is a cluster, eg: dot -Tsvg arch.dot
+func (graph DependencyGraph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph archunit {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	layers := lo.Keys(graph.Layers)
+	sort.Strings(layers)
+	clustered := lo.Flatten(lo.Values(graph.Layers))
+	lo.ForEach(layers, func(layer string, i int) {
+		sb.WriteString(fmt.Sprintf("\tsubgraph cluster_%d {\n\t\tlabel=%q;\n", i, layer))
+		lo.ForEach(graph.Layers[layer], func(pkg string, _ int) {
+			sb.WriteString(fmt.Sprintf("\t\t%q [label=%q];\n", pkg, graph.label(pkg)))
+		})
+		sb.WriteString("\t}\n")
+	})
+	lo.ForEach(graph.Packages, func(pkg string, _ int) {
+		if !lo.Contains(clustered, pkg) {
+			sb.WriteString(fmt.Sprintf("\t%q [label=%q];\n", pkg, graph.label(pkg)))
+		}
+	})
+	lo.ForEach(graph.Packages, func(pkg string, _ int) {
+		lo.ForEach(graph.Imports[pkg], func(imported string, _ int) {
+			sb.WriteString(fmt.Sprintf("\t%q -> %q;\n", pkg, imported))
+		})
+	})
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
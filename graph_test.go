@@ -0,0 +1,38 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestArchitecture_DependencyGraph(t *testing.T) {
+	arch, err := Load("testdata/cycle")
+	assert.NoError(t, err)
+	graph := arch.DependencyGraph()
+	assert.Equal(t, []string{"example.com/cycle/model", "example.com/cycle/model/dto", "example.com/cycle/service"}, graph.Packages)
+	assert.Equal(t, map[string][]string{
+		"example.com/cycle/model/dto": {"example.com/cycle/service"},
+		"example.com/cycle/service":   {"example.com/cycle/model"},
+	}, graph.Imports)
+	assert.Empty(t, graph.Layers)
+	var sb strings.Builder
+	assert.NoError(t, arch.DependencyGraph(Layers().Layer("model", "cycle/model/...")).WriteDOT(&sb))
+	assert.Equal(t, `digraph archunit {
+	rankdir=LR;
+	node [shape=box];
+	subgraph cluster_0 {
+		label="model";
+		"example.com/cycle/model" [label="model"];
+		"example.com/cycle/model/dto" [label="model/dto"];
+	}
+	"example.com/cycle/service" [label="service"];
+	"example.com/cycle/model/dto" -> "example.com/cycle/service";
+	"example.com/cycle/service" -> "example.com/cycle/model";
+}
+`, sb.String())
+	controller, _ := Layer("sample/controller/...")
+	graph = Project().DependencyGraph(Layers(controller))
+	assert.Len(t, graph.Layers, 1)
+	assert.Contains(t, graph.Layers[controller.Name()], "github.com/kcmvp/archunit/internal/sample/controller/module1")
+}
@@ -0,0 +1,45 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/token"
+)
+
+// UserFacingFunctions is the functions rendering messages to the users, named as CallersOf. it covers net/http, gin
+// and echo by default, append the rendering functions of other frameworks to it
+var UserFacingFunctions = lo.Flatten([][]string{
+	{"net/http.Error"},
+	methodsOf("github.com/gin-gonic/gin.Context", "String", "HTML", "AbortWithError"),
+	methodsOf("github.com/labstack/echo/v4.Context", "String", "HTML"),
+	{"github.com/labstack/echo/v4.NewHTTPError"},
+})
+
+// UserFacingStringsShouldComeFromMessageCatalog checks the packages of the selection do not pass string literals to
+// UserFacingFunctions, the messages should be looked up from the message catalog of the i18n package instead.
+// literals passed to other calls in the arguments, eg: i18n.T("key"), are not user facing
+func UserFacingStringsShouldComeFromMessageCatalog(pkgs ArchPackage) error {
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			if !funcMatches(site.Callee().Raw(), UserFacingFunctions...) {
+				return
+			}
+			if caller, ok := site.Caller(); ok && ignoredFunction("UserFacingStringsShouldComeFromMessageCatalog", caller) {
+				return
+			}
+			lo.ForEach(site.Expr().Args, func(arg ast.Expr, _ int) {
+				ast.Inspect(arg, func(node ast.Node) bool {
+					if lit, ok := node.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						result = append(result, violationAt(site.Position(), fmt.Sprintf("%s %s", callSite(site), lit.Value)))
+					}
+					_, call := node.(*ast.CallExpr)
+					return !call
+				})
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("user facing strings do not come from the message catalog: %w"), result.sorted())).Else(nil)
+}
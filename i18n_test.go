@@ -0,0 +1,20 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestUserFacingStringsShouldComeFromMessageCatalog(t *testing.T) {
+	assert.NoError(t, UserFacingStringsShouldComeFromMessageCatalog(AllPackages()))
+	arch, err := Load("testdata/i18n")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("i18n")
+	assert.NoError(t, err)
+	err = UserFacingStringsShouldComeFromMessageCatalog(pkgs)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "example.com/i18n.Literal -> net/http.Error \"bad request: \""))
+}
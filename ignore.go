@@ -0,0 +1,44 @@
+package archunit
+
+import (
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/types"
+	"strings"
+	"time"
+)
+
+// now is the clock used to check the expiry of the ignore directives
+var now = time.Now
+
+// ignored reports whether the declaration is exempted from the rule by the directive
+// `//archunit:ignore rule [until=2006-01-02]` on its declaration or on the package clause.
+// the exemption is void after the until date, so a temporary exception fails again once it expires.
+// rules are named by the function name or Selection.Method, eg: FeatureFlags.ShouldBeCheckedOnlyIn
+func (arch Architecture) ignored(rule string, obj types.Object) bool {
+	if obj == nil || obj.Pkg() == nil {
+		return false
+	}
+	pkg := arch.artifact.Package(obj.Pkg().Path())
+	if pkg == nil {
+		return false
+	}
+	return lo.SomeBy(append(pkg.Directive(obj, "ignore"), pkg.PackageDirective("ignore")...), func(value string) bool {
+		fields := strings.Fields(value)
+		if len(fields) == 0 || fields[0] != rule {
+			return false
+		}
+		for _, field := range fields[1:] {
+			if until, ok := strings.CutPrefix(field, "until="); ok {
+				date, err := time.ParseInLocation(time.DateOnly, until, time.Local)
+				return err == nil && now().Before(date.AddDate(0, 0, 1))
+			}
+		}
+		return true
+	})
+}
+
+// ignoredFunction reports whether the function is exempted from the rule in the architecture the function is loaded by
+func ignoredFunction(rule string, f internal.Function) bool {
+	return Architecture{artifact: f.Artifact()}.ignored(rule, f.Raw())
+}
@@ -0,0 +1,43 @@
+package archunit
+
+import (
+	"github.com/kcmvp/archunit/internal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIgnored(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+	typ, _ := internal.Arch().Type("internal/sample/service.UserService")
+	methods := map[string]*internal.Function{}
+	for _, f := range typ.Methods() {
+		methods[f.Name()] = &f
+	}
+	tests := []struct {
+		name    string
+		method  string
+		rule    string
+		today   string
+		ignored bool
+	}{
+		{"permanent", "GetUserByNameAndAddress", "ExportedFunctionsShouldHaveTests", "2099-12-30", true},
+		{"last day", "GetUserByNameAndAddress", "ExportedFunctionsShouldHaveTests", "2099-12-31", true},
+		{"expired", "GetUserByNameAndAddress", "ExportedFunctionsShouldHaveTests", "2100-01-01", false},
+		{"other rule", "GetUserByNameAndAddress", "StableAPIShouldNotDependOnExperimental", "2024-01-01", false},
+		{"in time", "SearchUsersByFirstName", "ExportedFunctionsShouldHaveTests", "2024-06-30", true},
+		{"expired", "SearchUsersByFirstName", "ExportedFunctionsShouldHaveTests", "2024-07-01", false},
+		{"no directive", "GetUserById", "ExportedFunctionsShouldHaveTests", "2024-01-01", false},
+	}
+	for _, test := range tests {
+		t.Run(test.method+"_"+test.name, func(t *testing.T) {
+			today, _ := time.ParseInLocation(time.DateOnly, test.today, time.Local)
+			now = func() time.Time {
+				return today.Add(12 * time.Hour)
+			}
+			assert.Equal(t, test.ignored, Project().ignored(test.rule, methods[test.method].Raw()))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"golang.org/x/tools/go/packages"
+	"sort"
+	"strings"
+)
+
+// PackagesWithInitShouldNotDependOnEachOther checks no package with init functions depends on another package with
+// init functions, directly or transitively, within the project. the init order of such packages is implied by
+// the imports and breaks silently when the imports change
+func PackagesWithInitShouldNotDependOnEachOther() error {
+	return Project().PackagesWithInitShouldNotDependOnEachOther()
+}
+
+// PackagesWithInitShouldNotDependOnEachOther checks the packages with init functions of the architecture, see the
+// top level one
+func (arch Architecture) PackagesWithInitShouldNotDependOnEachOther() error {
+	module := arch.Module()
+	inits := lo.Filter(arch.artifact.Packages(), func(pkg *internal.Package, _ int) bool {
+		return hasInit(pkg.Raw())
+	})
+	var result []string
+	lo.ForEach(inits, func(pkg *internal.Package, _ int) {
+		visited := map[string]bool{}
+		var walk func(raw *packages.Package, chain []string)
+		walk = func(raw *packages.Package, chain []string) {
+			paths := lo.Keys(raw.Imports)
+			sort.Strings(paths)
+			for _, path := range paths {
+				imported := raw.Imports[path]
+				if visited[path] || !strings.HasPrefix(path, module) {
+					continue
+				}
+				visited[path] = true
+				if hasInit(imported) {
+					result = append(result, strings.Join(append(chain, path), " -> "))
+				}
+				walk(imported, append(chain, path))
+			}
+		}
+		walk(pkg.Raw(), []string{pkg.ID()})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages with init depend on each other: %w"), Violations(result))).Else(nil)
+}
+
+// hasInit reports whether the package declares init functions
+func hasInit(pkg *packages.Package) bool {
+	return lo.SomeBy(pkg.Syntax, func(file *ast.File) bool {
+		return lo.SomeBy(file.Decls, func(decl ast.Decl) bool {
+			fd, ok := decl.(*ast.FuncDecl)
+			return ok && fd.Recv == nil && fd.Name.Name == "init"
+		})
+	})
+}
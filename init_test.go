@@ -0,0 +1,20 @@
+package archunit_test
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/kcmvp/archunit/archtest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPackagesWithInitShouldNotDependOnEachOther(t *testing.T) {
+	assert.NoError(t, archunit.PackagesWithInitShouldNotDependOnEachOther())
+	err := archtest.LoadFixture(t, "testdata/initchain").PackagesWithInitShouldNotDependOnEachOther()
+	var violations archunit.Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.ElementsMatch(t, archunit.Violations{
+		"fixture/db -> fixture/config",
+		"fixture/app -> fixture/api -> fixture/db",
+		"fixture/app -> fixture/api -> fixture/db -> fixture/config",
+	}, violations)
+}
@@ -1,13 +1,19 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/fatih/color"
 	"github.com/samber/lo"
 	lop "github.com/samber/lo/parallel"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"go/types"
 	"log"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -23,36 +29,75 @@ const (
 	ParseVar
 )
 
+const directivePrefix = "//archunit:"
+
+const loadMode = packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedFiles | packages.NeedDeps | packages.NeedImports | packages.NeedSyntax
+
 var (
 	once sync.Once
 	arch *Artifact
 )
 
 type Package struct {
-	raw          *packages.Package
-	constantsDef []string
-	functions    []Function
-	types        []Type
+	artifact      *Artifact
+	raw           *packages.Package
+	constantsDef  []string
+	constants     []Constant
+	functions     []Function
+	types         []Type
+	variables     []Variable
+	testOnce      sync.Once
+	testFiles     []*ast.File
+	directiveOnce sync.Once
+	directives    map[types.Object]map[string][]string
+	pkgDirectives map[string][]string
+	callOnce      sync.Once
+	callSites     []CallSite
+	declOnce      sync.Once
+	decls         map[types.Object]ast.Node
+	closureOnce   sync.Once
+	closures      []Function
 }
 
 type Param lo.Tuple2[string, string]
 
 type Function struct {
-	raw *types.Func
+	artifact *Artifact
+	raw      *types.Func
+	lit      *ast.FuncLit
 }
 
 type Type struct {
-	raw *types.TypeName
+	artifact *Artifact
+	raw      *types.TypeName
+}
+
+// Constant is a package level constant
+type Constant struct {
+	artifact *Artifact
+	raw      *types.Const
+}
+
+// Field is a field of a struct type
+type Field struct {
+	raw *types.Var
+	tag string
 }
 
+// Variable is a package level variable
 type Variable struct {
-	pkg string
-	raw *types.Named
+	artifact *Artifact
+	raw      *types.Var
 }
 type Artifact struct {
-	rootDir string
-	module  string
-	pkgs    sync.Map
+	rootDir   string
+	module    string
+	fset      *token.FileSet
+	pkgs      sync.Map
+	testOnce  sync.Once
+	testFuncs map[string][]Function
+	testPkgs  map[string][]*packages.Package
+	indexed   bool
 }
 
 func (artifact *Artifact) RootDir() string {
@@ -63,32 +108,109 @@ func (artifact *Artifact) Module() string {
 	return artifact.module
 }
 
+// Arch returns the default artifact of current module, use Load for the other modules
 func Arch() *Artifact {
 	once.Do(func() {
-		cmd := exec.Command("go", "list", "-m", "-f", "{{.Dir}}:{{.Path}}")
-		output, err := cmd.Output()
-		if err != nil {
+		var err error
+		if arch, err = Load(""); arch == nil {
 			log.Fatal("Error executing go list command:", err)
-		}
-		item := strings.Split(strings.TrimSpace(string(output)), ":")
-		arch = &Artifact{rootDir: item[0], module: item[1]}
-		cfg := &packages.Config{
-			Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedFiles | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports | packages.NeedSyntax,
-			Dir:  arch.rootDir,
-		}
-		pkgs, err := packages.Load(cfg, "./...")
-		if err != nil {
+		} else if err != nil {
 			color.Red("Error loading project: %w", err)
-			return
 		}
-		lop.ForEach(pkgs, func(pkg *packages.Package, _ int) {
-			arch.pkgs.Store(pkg.ID, parse(pkg, ParseCon|ParseFun|ParseTyp))
-		})
 	})
 	return arch
 }
-func parse(pkg *packages.Package, mode ParseMode) *Package {
-	archPkg := &Package{raw: pkg}
+
+// Load loads the go module in the directory, current directory is used when dir is empty.
+// the artifact is returned with the error if the module is found but its packages fail to load
+func Load(dir string) (*Artifact, error) {
+	artifact, err := module(dir)
+	if err != nil {
+		return nil, err
+	}
+	return artifact, artifact.load("./...")
+}
+
+// LoadPackages loads the packages of the patterns of the go module in the directory, only the packages of the
+// patterns are kept in the artifact, so the memory is bounded by the patterns and their dependencies rather than the
+// whole module. the artifact is returned with the error if the packages fail to load
+func LoadPackages(dir string, patterns ...string) (*Artifact, error) {
+	artifact, err := module(dir)
+	if err != nil {
+		return nil, err
+	}
+	return artifact, artifact.load(patterns...)
+}
+
+// PackageOrder returns the packages of the go module in the directory in the dependency order, a package comes after
+// all the packages of the module it imports
+func PackageOrder(dir string) ([]string, error) {
+	artifact, err := module(dir)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedImports, Dir: artifact.rootDir}, "./...")
+	if err != nil {
+		return nil, err
+	}
+	imports := map[string][]string{}
+	lo.ForEach(pkgs, func(pkg *packages.Package, _ int) {
+		imports[pkg.ID] = lo.Keys(pkg.Imports)
+	})
+	return dependencyOrder(imports), nil
+}
+
+// module returns the empty artifact of the go module in the directory
+func module(dir string) (*Artifact, error) {
+	cmd := exec.Command("go", "list", "-m", "-f", "{{.Dir}}:{{.Path}}")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	item := strings.Split(strings.TrimSpace(string(output)), ":")
+	return &Artifact{rootDir: item[0], module: item[1], fset: token.NewFileSet()}, nil
+}
+
+// Requires returns the paths of the modules required by the go.mod of the module, including the indirect ones
+func (artifact *Artifact) Requires() ([]string, error) {
+	cmd := exec.Command("go", "mod", "edit", "-json")
+	cmd.Dir = artifact.rootDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	var mod struct {
+		Require []struct {
+			Path string
+		}
+	}
+	if err = json.Unmarshal(output, &mod); err != nil {
+		return nil, err
+	}
+	return lo.Map(mod.Require, func(require struct{ Path string }, _ int) string {
+		return require.Path
+	}), nil
+}
+
+func (artifact *Artifact) load(patterns ...string) error {
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  artifact.rootDir,
+		Fset: artifact.fset,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+	lop.ForEach(pkgs, func(pkg *packages.Package, _ int) {
+		artifact.pkgs.Store(pkg.ID, artifact.parse(pkg, ParseCon|ParseFun|ParseTyp|ParseVar))
+	})
+	return nil
+}
+
+func (artifact *Artifact) parse(pkg *packages.Package, mode ParseMode) *Package {
+	archPkg := &Package{artifact: artifact, raw: pkg}
 	typPkg := pkg.Types
 	scope := typPkg.Scope()
 	lo.ForEach(scope.Names(), func(name string, _ int) {
@@ -96,22 +218,26 @@ func parse(pkg *packages.Package, mode ParseMode) *Package {
 		file := pkg.Fset.Position(obj.Pos()).Filename
 		switch vType := obj.(type) {
 		case *types.Const:
-			if ParseCon&mode == ParseCon && !lo.Contains(archPkg.constantsDef, file) {
-				archPkg.constantsDef = append(archPkg.constantsDef, file)
+			if ParseCon&mode == ParseCon {
+				if !lo.Contains(archPkg.constantsDef, file) {
+					archPkg.constantsDef = append(archPkg.constantsDef, file)
+				}
+				archPkg.constants = append(archPkg.constants, Constant{artifact: artifact, raw: vType})
 			}
 		case *types.Func:
 			if ParseFun&mode == ParseFun {
-				archPkg.functions = append(archPkg.functions, Function{raw: vType})
+				archPkg.functions = append(archPkg.functions, Function{artifact: artifact, raw: vType})
 			}
 		case *types.TypeName:
 			if ParseTyp&mode == ParseTyp {
-				if _, ok := vType.Type().(*types.Named); ok {
-					archPkg.types = append(archPkg.types, Type{raw: vType})
+				// the aliases, eg: type Type = internal.Type, are the types declared in the other packages
+				if _, ok := vType.Type().(*types.Named); ok && !vType.IsAlias() {
+					archPkg.types = append(archPkg.types, Type{artifact: artifact, raw: vType})
 				}
 			}
 		case *types.Var:
 			if ParseVar&mode == ParseVar {
-				panic("unreachable")
+				archPkg.variables = append(archPkg.variables, Variable{artifact: artifact, raw: vType})
 			}
 		}
 	})
@@ -160,7 +286,7 @@ func (artifact *Artifact) Type(typName string) (Type, bool) {
 		for _, e := range artifact.Packages() {
 			if strings.HasPrefix(e.ID(), artifact.Module()) {
 				if raw, ok := e.raw.Imports[pkgName]; ok {
-					pkg = parse(raw, ParseTyp|ParseFun)
+					pkg = artifact.parse(raw, ParseTyp|ParseFun)
 					artifact.pkgs.Store(pkgName, pkg)
 					break
 				}
@@ -175,6 +301,55 @@ func (artifact *Artifact) Type(typName string) (Type, bool) {
 	})
 }
 
+// testFunctions returns the functions declared in the _test.go files of the package, including
+// the ones of the external test(package xxx_test). test packages are loaded on demand with the same FileSet
+func (artifact *Artifact) testFunctions(id string) []Function {
+	artifact.loadTests()
+	return artifact.testFuncs[id]
+}
+
+// loadTests loads the test variants of the packages once, the test packages are not available for the artifacts
+// loaded from an index
+func (artifact *Artifact) loadTests() {
+	artifact.testOnce.Do(func() {
+		artifact.testFuncs = map[string][]Function{}
+		artifact.testPkgs = map[string][]*packages.Package{}
+		if artifact.indexed {
+			return
+		}
+		cfg := &packages.Config{
+			Mode:  loadMode,
+			Dir:   artifact.rootDir,
+			Fset:  artifact.fset,
+			Tests: true,
+		}
+		pkgs, err := packages.Load(cfg, "./...")
+		if err != nil {
+			color.Red("Error loading tests: %w", err)
+			return
+		}
+		for _, pkg := range pkgs {
+			// test files only exist in the test variants: "xxx [xxx.test]" and "xxx_test [xxx.test]"
+			if !strings.HasSuffix(pkg.ID, ".test]") {
+				continue
+			}
+			pkgID := strings.TrimSuffix(pkg.PkgPath, "_test")
+			artifact.testPkgs[pkgID] = append(artifact.testPkgs[pkgID], pkg)
+			scope := pkg.Types.Scope()
+			for _, name := range scope.Names() {
+				if f, ok := scope.Lookup(name).(*types.Func); ok && strings.HasSuffix(artifact.fset.Position(f.Pos()).Filename, "_test.go") {
+					artifact.testFuncs[pkgID] = append(artifact.testFuncs[pkgID], Function{artifact: artifact, raw: f})
+				}
+			}
+		}
+	})
+}
+
+// Artifact returns the artifact the package is loaded by
+func (pkg *Package) Artifact() *Artifact {
+	return pkg.artifact
+}
+
 func (pkg *Package) Raw() *packages.Package {
 	return pkg.raw
 }
@@ -183,14 +358,36 @@ func (pkg *Package) ConstantFiles() []string {
 	return pkg.constantsDef
 }
 
+// Constants returns the package level constants of the package
+func (pkg *Package) Constants() []Constant {
+	return pkg.constants
+}
+
 func (pkg *Package) Functions() []Function {
 	return pkg.functions
 }
 
+// TestFunctions returns the functions declared in the _test.go files of the package
+func (pkg *Package) TestFunctions() []Function {
+	return pkg.artifact.testFunctions(pkg.ID())
+}
+
+// TestPackages returns the type checked test variants of the package, the internal test "xxx [xxx.test]" which also
+// includes the files of the package and the external test "xxx_test [xxx.test]"
+func (pkg *Package) TestPackages() []*packages.Package {
+	pkg.artifact.loadTests()
+	return pkg.artifact.testPkgs[pkg.ID()]
+}
+
 func (pkg *Package) Types() []Type {
 	return pkg.types
 }
 
+// Variables returns the package level variables of the package
+func (pkg *Package) Variables() []Variable {
+	return pkg.variables
+}
+
 func (pkg *Package) ID() string {
 	return pkg.raw.ID
 }
@@ -207,11 +404,81 @@ func (pkg *Package) Name() string {
 	return pkg.raw.Name
 }
 
+// TestGoFiles returns the paths of the _test.go files in the directory of the package
+func (pkg *Package) TestGoFiles() []string {
+	if len(pkg.raw.GoFiles) == 0 {
+		return nil
+	}
+	files, _ := filepath.Glob(filepath.Join(filepath.Dir(pkg.raw.GoFiles[0]), "*_test.go"))
+	return files
+}
+
+// TestFiles returns the parsed _test.go files in the package folder, both the internal tests
+// and the external tests(package xxx_test). The files are parsed lazily on the first call
+func (pkg *Package) TestFiles() []*ast.File {
+	pkg.testOnce.Do(func() {
+		for _, file := range pkg.TestGoFiles() {
+			if f, err := parser.ParseFile(pkg.raw.Fset, file, nil, parser.ParseComments); err == nil {
+				pkg.testFiles = append(pkg.testFiles, f)
+			}
+		}
+	})
+	return pkg.testFiles
+}
+
 func (typ Type) Interface() bool {
 	_, ok := typ.Raw().Underlying().(*types.Interface)
 	return ok
 }
 
+// Struct reports whether the underlying type is a struct
+func (typ Type) Struct() bool {
+	_, ok := typ.Raw().Underlying().(*types.Struct)
+	return ok
+}
+
+// Fields returns the fields of the struct, nil for the other types
+func (typ Type) Fields() []Field {
+	st, ok := typ.Raw().Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	return lo.Map(lo.Range(st.NumFields()), func(i int, _ int) Field {
+		return Field{raw: st.Field(i), tag: st.Tag(i)}
+	})
+}
+
+func (f Field) Raw() *types.Var {
+	return f.raw
+}
+
+func (f Field) Name() string {
+	return f.raw.Name()
+}
+
+// Type returns the type of the field qualified by the package path, eg: context.Context or *database/sql.DB
+func (f Field) Type() string {
+	return f.raw.Type().String()
+}
+
+// Tag returns the raw tag of the field, eg: `json:"name"`
+func (f Field) Tag() string {
+	return f.tag
+}
+
+// TagValue returns the value of the key in the tag of the field, eg: name,omitempty of `json:"name,omitempty"`
+func (f Field) TagValue(key string) (string, bool) {
+	return reflect.StructTag(f.tag).Lookup(key)
+}
+
+func (f Field) Embedded() bool {
+	return f.raw.Embedded()
+}
+
+func (f Field) Exported() bool {
+	return f.raw.Exported()
+}
+
 func (typ Type) Package() string {
 	return typ.Raw().Obj().Pkg().Path()
 }
@@ -221,6 +488,11 @@ func (typ Type) FuncType() bool {
 	return ok
 }
 
+// Artifact returns the artifact the type is loaded by
+func (typ Type) Artifact() *Artifact {
+	return typ.artifact
+}
+
 func (typ Type) Raw() *types.Named {
 	return typ.raw.Type().(*types.Named)
 }
@@ -230,7 +502,7 @@ func (typ Type) Name() string {
 }
 
 func (typ Type) GoFile() string {
-	return Arch().Package(typ.Package()).raw.Fset.Position(typ.Raw().Obj().Pos()).Filename
+	return typ.artifact.fset.Position(typ.Raw().Obj().Pos()).Filename
 }
 
 func (typ Type) Exported() bool {
@@ -243,27 +515,133 @@ func (typ Type) Methods() []Function {
 		iTyp := typ.Raw().Underlying().(*types.Interface)
 		n := iTyp.NumMethods()
 		for i := 0; i < n; i++ {
-			functions = append(functions, Function{raw: iTyp.Method(i)})
+			functions = append(functions, Function{artifact: typ.artifact, raw: iTyp.Method(i)})
 		}
 	} else {
 		n := typ.Raw().NumMethods()
 		for i := 0; i < n; i++ {
-			functions = append(functions, Function{raw: typ.Raw().Method(i)})
+			functions = append(functions, Function{artifact: typ.artifact, raw: typ.Raw().Method(i)})
 		}
 	}
 	return functions
 }
 
+// Artifact returns the artifact the constant is loaded by
+func (c Constant) Artifact() *Artifact {
+	return c.artifact
+}
+
+func (c Constant) Raw() *types.Const {
+	return c.raw
+}
+
+func (c Constant) Name() string {
+	return c.raw.Name()
+}
+
+// FullName returns the name qualified by the package path, eg: github.com/kcmvp/archunit/internal.ParseCon
+func (c Constant) FullName() string {
+	return fmt.Sprintf("%s.%s", c.Package(), c.raw.Name())
+}
+
+func (c Constant) Package() string {
+	return c.raw.Pkg().Path()
+}
+
+// Type returns the type of the constant, eg: int or untyped string
+func (c Constant) Type() string {
+	return c.raw.Type().String()
+}
+
+// Value returns the exact value of the constant, eg: 1 or "ok"
+func (c Constant) Value() string {
+	return c.raw.Val().ExactString()
+}
+
+func (c Constant) GoFile() string {
+	return c.artifact.fset.Position(c.raw.Pos()).Filename
+}
+
+func (c Constant) Exported() bool {
+	return c.raw.Exported()
+}
+
+func (v Variable) Raw() *types.Var {
+	return v.raw
+}
+
+func (v Variable) Name() string {
+	return v.raw.Name()
+}
+
+// FullName returns the name qualified by the package path, eg: github.com/kcmvp/archunit/internal.arch
+func (v Variable) FullName() string {
+	return fmt.Sprintf("%s.%s", v.raw.Pkg().Path(), v.raw.Name())
+}
+
+// Artifact returns the artifact the variable is loaded by
+func (v Variable) Artifact() *Artifact {
+	return v.artifact
+}
+
+// Artifact returns the artifact the function is loaded by
+func (f Function) Artifact() *Artifact {
+	return f.artifact
+}
+
+func (f Function) Raw() *types.Func {
+	return f.raw
+}
+
 func (f Function) Name() string {
 	return f.raw.Name()
 }
 
+func (f Function) FullName() string {
+	return f.raw.FullName()
+}
+
+func (f Function) Exported() bool {
+	return f.raw.Exported()
+}
+
+// Method reports whether the function has a receiver
+func (f Function) Method() bool {
+	return f.raw.Type().(*types.Signature).Recv() != nil
+}
+
+// Package returns the path of the package declaring the function, empty for the methods of universe types, eg: error.Error
 func (f Function) Package() string {
+	if f.raw.Pkg() == nil {
+		return ""
+	}
 	return f.raw.Pkg().Path()
 }
 
 func (f Function) GoFile() string {
-	return Arch().Package(f.Package()).raw.Fset.Position(f.raw.Pos()).Filename
+	return f.artifact.fset.Position(f.raw.Pos()).Filename
+}
+
+// Benchmark reports whether the function is a benchmark, func BenchmarkXxx(*testing.B)
+func (f Function) Benchmark() bool {
+	return f.testing("Benchmark", "*testing.B")
+}
+
+// Fuzz reports whether the function is a fuzz test, func FuzzXxx(*testing.F)
+func (f Function) Fuzz() bool {
+	return f.testing("Fuzz", "*testing.F")
+}
+
+// Example reports whether the function is an example, func ExampleXxx()
+func (f Function) Example() bool {
+	sig := f.raw.Type().(*types.Signature)
+	return strings.HasPrefix(f.Name(), "Example") && sig.Recv() == nil && sig.Params().Len() == 0 && sig.Results().Len() == 0
+}
+
+func (f Function) testing(prefix, param string) bool {
+	sig := f.raw.Type().(*types.Signature)
+	return strings.HasPrefix(f.Name(), prefix) && sig.Recv() == nil && sig.Params().Len() == 1 &&
+		sig.Params().At(0).Type().String() == param
 }
 
 func (f Function) Params() []Param {
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// CallSite is a function or method call in the source code of a package
+type CallSite struct {
+	pkg    *Package
+	caller *types.Func
+	callee *types.Func
+	expr   *ast.CallExpr
+}
+
+// CallSites returns all the calls of the package whose callee can be resolved to a declared function or method,
+// calls of builtin functions, type conversions and function values are not included
+func (pkg *Package) CallSites() []CallSite {
+	pkg.callOnce.Do(func() {
+		for _, file := range pkg.raw.Syntax {
+			for _, decl := range file.Decls {
+				var caller *types.Func
+				if fd, ok := decl.(*ast.FuncDecl); ok {
+					caller, _ = pkg.raw.TypesInfo.Defs[fd.Name].(*types.Func)
+				}
+				ast.Inspect(decl, func(node ast.Node) bool {
+					if expr, ok := node.(*ast.CallExpr); ok {
+						if callee, ok := typeutil.Callee(pkg.raw.TypesInfo, expr).(*types.Func); ok {
+							pkg.callSites = append(pkg.callSites, CallSite{pkg: pkg, caller: caller, callee: callee, expr: expr})
+						}
+					}
+					return true
+				})
+			}
+		}
+	})
+	return pkg.callSites
+}
+
+func (site CallSite) Package() *Package {
+	return site.pkg
+}
+
+// Caller returns the function in which the call happens, returns false when the call happens in package level
+// initialization
+func (site CallSite) Caller() (Function, bool) {
+	return Function{artifact: site.pkg.artifact, raw: site.caller}, site.caller != nil
+}
+
+func (site CallSite) Callee() Function {
+	return Function{artifact: site.pkg.artifact, raw: site.callee}
+}
+
+func (site CallSite) Expr() *ast.CallExpr {
+	return site.expr
+}
+
+func (site CallSite) Position() token.Position {
+	return site.pkg.raw.Fset.Position(site.expr.Pos())
+}
+
+// CallSites returns the calls in the body of the function, returns nil for functions out of the loaded packages
+func (f Function) CallSites() []CallSite {
+	var sites []CallSite
+	if pkg := f.artifact.Package(f.Package()); pkg != nil {
+		for _, site := range pkg.CallSites() {
+			if site.caller == f.raw {
+				sites = append(sites, site)
+			}
+		}
+	}
+	return sites
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// Closures returns the significant function literals of the package: the literals assigned to the package level
+// variables and the literals returned by the exported functions. the closures are named after the variables, eg:
+// auditLog, or after the enclosing functions the way the compiler does, eg: NewHandler.func1
+func (pkg *Package) Closures() []Function {
+	pkg.closureOnce.Do(func() {
+		if pkg.raw.TypesInfo == nil {
+			return
+		}
+		for _, file := range pkg.raw.Syntax {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						if s, ok := spec.(*ast.ValueSpec); ok && len(s.Names) == len(s.Values) {
+							for i, value := range s.Values {
+								if lit, ok := ast.Unparen(value).(*ast.FuncLit); ok && s.Names[i].Name != "_" {
+									pkg.closures = append(pkg.closures, pkg.closure(lit, s.Names[i].Name))
+								}
+							}
+						}
+					}
+				case *ast.FuncDecl:
+					if d.Body != nil && d.Name.IsExported() {
+						for i, lit := range returnedLiterals(d.Body) {
+							pkg.closures = append(pkg.closures, pkg.closure(lit, fmt.Sprintf("%s.func%d", funcName(d), i+1)))
+						}
+					}
+				}
+			}
+		}
+	})
+	return pkg.closures
+}
+
+// closure returns the function of the literal, the function is not an object of the package scope
+func (pkg *Package) closure(lit *ast.FuncLit, name string) Function {
+	sig, _ := pkg.raw.TypesInfo.TypeOf(lit).(*types.Signature)
+	return Function{artifact: pkg.artifact, raw: types.NewFunc(lit.Pos(), pkg.raw.Types, name, sig), lit: lit}
+}
+
+// returnedLiterals returns the function literals returned by the body, the returns of the nested literals are skipped
+func returnedLiterals(body *ast.BlockStmt) []*ast.FuncLit {
+	var lits []*ast.FuncLit
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if lit, ok := ast.Unparen(result).(*ast.FuncLit); ok {
+					lits = append(lits, lit)
+				}
+			}
+		}
+		return true
+	})
+	return lits
+}
+
+// funcName returns the name of the declared function, qualified by the receiver type for the methods
+func funcName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	recv := decl.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return fmt.Sprintf("%s.%s", t.Name, decl.Name.Name)
+		}
+		return decl.Name.Name
+	}
+}
+
+// Literal returns the function literal of the closure, returns nil for the declared functions
+func (f Function) Literal() *ast.FuncLit {
+	return f.lit
+}
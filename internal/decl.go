@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Decl returns the syntax node which declares the package level object: *ast.FuncDecl for functions and methods,
+// *ast.TypeSpec for types and *ast.ValueSpec for constants and variables. returns nil when the object is not
+// declared in the package source
+func (pkg *Package) Decl(obj types.Object) ast.Node {
+	return pkg.declarations()[obj]
+}
+
+// declarations returns the syntax nodes of the package level objects declared in the package source
+func (pkg *Package) declarations() map[types.Object]ast.Node {
+	pkg.declOnce.Do(func() {
+		pkg.decls = map[types.Object]ast.Node{}
+		for _, file := range pkg.raw.Syntax {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					pkg.decls[pkg.raw.TypesInfo.Defs[d.Name]] = d
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						switch s := spec.(type) {
+						case *ast.TypeSpec:
+							pkg.decls[pkg.raw.TypesInfo.Defs[s.Name]] = s
+						case *ast.ValueSpec:
+							for _, name := range s.Names {
+								pkg.decls[pkg.raw.TypesInfo.Defs[name]] = s
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+	return pkg.decls
+}
+
+// Decl returns the declaration of the function, returns nil for interface methods and functions
+// out of the loaded packages
+func (f Function) Decl() *ast.FuncDecl {
+	if pkg := f.artifact.Package(f.Package()); pkg != nil {
+		decl, _ := pkg.Decl(f.raw).(*ast.FuncDecl)
+		return decl
+	}
+	return nil
+}
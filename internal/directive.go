@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// Directive returns the values of the `//archunit:name` directives declared in the doc of the object
+func (pkg *Package) Directive(obj types.Object, name string) []string {
+	pkg.directiveOnce.Do(pkg.parseDirectives)
+	return pkg.directives[obj][name]
+}
+
+// PackageDirective returns the values of the `//archunit:name` directives declared in the package clause doc
+func (pkg *Package) PackageDirective(name string) []string {
+	pkg.directiveOnce.Do(pkg.parseDirectives)
+	return pkg.pkgDirectives[name]
+}
+
+func (pkg *Package) parseDirectives() {
+	pkg.directives = map[types.Object]map[string][]string{}
+	pkg.pkgDirectives = map[string][]string{}
+	bind := func(name *ast.Ident, docs ...*ast.CommentGroup) {
+		if obj := pkg.raw.TypesInfo.Defs[name]; obj != nil {
+			if _, ok := pkg.directives[obj]; !ok {
+				pkg.directives[obj] = map[string][]string{}
+			}
+			for _, doc := range docs {
+				mergeDirectives(pkg.directives[obj], doc)
+			}
+		}
+	}
+	for _, file := range pkg.raw.Syntax {
+		mergeDirectives(pkg.pkgDirectives, file.Doc)
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				bind(d.Name, d.Doc)
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						bind(s.Name, d.Doc, s.Doc)
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							bind(name, d.Doc, s.Doc)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// mergeDirectives parses directives in the form of `//archunit:name=value` or `//archunit:name value`
+func mergeDirectives(directives map[string][]string, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, comment := range doc.List {
+		if text, ok := strings.CutPrefix(comment.Text, directivePrefix); ok {
+			name, value := text, ""
+			if idx := strings.IndexAny(text, "= "); idx > 0 {
+				name, value = text[:idx], strings.TrimSpace(text[idx+1:])
+			}
+			directives[name] = append(directives[name], value)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+
+	"golang.org/x/tools/go/gcexportdata"
+	"golang.org/x/tools/go/packages"
+)
+
+// Index is the pre-exported package index of a go module. the artifact is loaded from the index without running the
+// go command, so the analysis can run where the go toolchain is absent, eg: WASM. the packages out of the index, eg:
+// the standard library, are kept as their export data keyed by the package path
+type Index struct {
+	RootDir  string            `json:"rootDir"`
+	Module   string            `json:"module"`
+	Packages []IndexPackage    `json:"packages"`
+	Exports  map[string][]byte `json:"exports,omitempty"`
+}
+
+// IndexPackage is a package of the index, the sources are keyed by the go files and read from the files when absent
+type IndexPackage struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	GoFiles []string          `json:"goFiles"`
+	Imports []string          `json:"imports"`
+	Sources map[string]string `json:"sources,omitempty"`
+}
+
+// majorVersion matches the major version suffix of the import paths, eg: /v2
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// Index exports the index of the artifact, the sources of the go files are embedded when sources is true. the export
+// data of the packages imported from out of the index is embedded, so the references to them are typed when the index
+// is loaded
+func (artifact *Artifact) Index(sources bool) (Index, error) {
+	index := Index{RootDir: artifact.rootDir, Module: artifact.module, Exports: map[string][]byte{}}
+	pkgs := artifact.Packages()
+	ids := lo.SliceToMap(pkgs, func(pkg *Package) (string, bool) {
+		return pkg.ID(), true
+	})
+	for _, pkg := range pkgs {
+		for id, imported := range pkg.raw.Imports {
+			if _, ok := index.Exports[id]; ok || ids[id] || imported.Types == nil || !imported.Types.Complete() {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := gcexportdata.Write(&buf, artifact.fset, imported.Types); err != nil {
+				return Index{}, fmt.Errorf("can not export %s: %w", id, err)
+			}
+			index.Exports[id] = buf.Bytes()
+		}
+	}
+	for _, pkg := range pkgs {
+		item := IndexPackage{ID: pkg.ID(), Name: pkg.Name(), GoFiles: pkg.GoFiles(), Imports: pkg.Imports()}
+		sort.Strings(item.Imports)
+		if sources {
+			item.Sources = map[string]string{}
+			for _, file := range pkg.GoFiles() {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return Index{}, err
+				}
+				item.Sources[file] = string(data)
+			}
+		}
+		index.Packages = append(index.Packages, item)
+	}
+	sort.Slice(index.Packages, func(i, j int) bool {
+		return index.Packages[i].ID < index.Packages[j].ID
+	})
+	return index, nil
+}
+
+// LoadIndex loads the artifact from the index without running the go command. the packages of the index are type
+// checked from their sources in the dependency order, the packages out of the index, eg: the standard library, are
+// imported from the export data of the index, the packages without the export data are imported as empty packages
+// so the references to them are left untyped, and reported as the type errors. the test functions are not available.
+// the type errors are collected in the errors of the packages
+func LoadIndex(index Index) (*Artifact, error) {
+	artifact := &Artifact{rootDir: index.RootDir, module: index.Module, fset: token.NewFileSet(), indexed: true}
+	items := lo.SliceToMap(index.Packages, func(item IndexPackage) (string, IndexPackage) {
+		return item.ID, item
+	})
+	loaded := map[string]*packages.Package{}
+	// the packages read from the export data share the packages they depend on
+	imports := map[string]*types.Package{}
+	external := func(id string) *packages.Package {
+		if pkg, ok := loaded[id]; ok {
+			return pkg
+		}
+		if data, ok := index.Exports[id]; ok {
+			if typPkg, err := gcexportdata.Read(bytes.NewReader(data), artifact.fset, imports, id); err == nil {
+				loaded[id] = &packages.Package{ID: id, PkgPath: id, Name: typPkg.Name(), Types: typPkg, Fset: artifact.fset}
+				return loaded[id]
+			}
+		}
+		name := path.Base(id)
+		if majorVersion.MatchString(name) && path.Dir(id) != "." {
+			name = path.Base(path.Dir(id))
+		}
+		typPkg := types.NewPackage(id, name)
+		typPkg.MarkComplete()
+		loaded[id] = &packages.Package{ID: id, PkgPath: id, Name: name, Types: typPkg, Fset: artifact.fset}
+		return loaded[id]
+	}
+	order := dependencyOrder(lo.MapValues(items, func(item IndexPackage, _ string) []string {
+		return item.Imports
+	}))
+	for _, id := range order {
+		item := items[id]
+		pkg := &packages.Package{ID: id, PkgPath: id, Name: item.Name, GoFiles: item.GoFiles, Fset: artifact.fset,
+			Imports: map[string]*packages.Package{}}
+		for _, file := range item.GoFiles {
+			var src any
+			if source, ok := item.Sources[file]; ok {
+				src = source
+			}
+			f, err := parser.ParseFile(artifact.fset, file, src, parser.ParseComments)
+			if err != nil {
+				return nil, fmt.Errorf("can not parse %s: %w", file, err)
+			}
+			pkg.Syntax = append(pkg.Syntax, f)
+		}
+		lo.ForEach(item.Imports, func(imported string, _ int) {
+			pkg.Imports[imported] = external(imported)
+		})
+		pkg.TypesInfo = &types.Info{
+			Types:      map[ast.Expr]types.TypeAndValue{},
+			Defs:       map[*ast.Ident]types.Object{},
+			Uses:       map[*ast.Ident]types.Object{},
+			Implicits:  map[ast.Node]types.Object{},
+			Selections: map[*ast.SelectorExpr]*types.Selection{},
+			Scopes:     map[ast.Node]*types.Scope{},
+			Instances:  map[*ast.Ident]types.Instance{},
+		}
+		cfg := &types.Config{
+			Importer: importerFunc(func(path string) (*types.Package, error) {
+				return external(path).Types, nil
+			}),
+			// the type errors are kept in the package as packages.Load does, the references to the empty packages are
+			// reported as well while the rest of the package is still checked
+			Error: func(err error) {
+				if typErr, ok := err.(types.Error); ok {
+					pkg.Errors = append(pkg.Errors, packages.Error{Pos: typErr.Fset.Position(typErr.Pos).String(),
+						Msg: typErr.Msg, Kind: packages.TypeError})
+				}
+			},
+		}
+		pkg.Types, _ = cfg.Check(id, artifact.fset, pkg.Syntax, pkg.TypesInfo)
+		loaded[id] = pkg
+		artifact.pkgs.Store(id, artifact.parse(pkg, ParseCon|ParseFun|ParseTyp|ParseVar))
+	}
+	return artifact, nil
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// dependencyOrder returns the packages of the import graph in the dependency order, a package comes after all the
+// packages of the graph it imports
+func dependencyOrder(imports map[string][]string) []string {
+	ids := lo.Keys(imports)
+	sort.Strings(ids)
+	visited := map[string]bool{}
+	var order []string
+	var visit func(id string)
+	visit = func(id string) {
+		visited[id] = true
+		deps := append([]string{}, imports[id]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := imports[dep]; ok && !visited[dep] {
+				visit(dep)
+			}
+		}
+		order = append(order, id)
+	}
+	lo.ForEach(ids, func(id string, _ int) {
+		if !visited[id] {
+			visit(id)
+		}
+	})
+	return order
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"github.com/samber/lo"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+)
+
+// Position returns the source position of the object declared in the loaded packages, eg: types, functions,
+// variables and constants. the position is invalid for the objects without source, eg: objects of export data
+func (artifact *Artifact) Position(obj types.Object) token.Position {
+	return artifact.fset.Position(obj.Pos())
+}
+
+func (typ Type) Position() token.Position {
+	return typ.artifact.Position(typ.raw)
+}
+
+func (f Function) Position() token.Position {
+	return f.artifact.Position(f.raw)
+}
+
+func (c Constant) Position() token.Position {
+	return c.artifact.Position(c.raw)
+}
+
+func (v Variable) Position() token.Position {
+	return v.artifact.Position(v.raw)
+}
+
+// Enclosing returns the package level object whose declaration encloses the line of the file, eg: the function in
+// which a call happens. returns false when the line is out of any declaration of the loaded packages
+func (artifact *Artifact) Enclosing(file string, line int) (types.Object, bool) {
+	var enclosing types.Object
+	span := 0
+	for _, pkg := range artifact.Packages() {
+		if !lo.ContainsBy(pkg.GoFiles(), func(goFile string) bool {
+			return filepath.Clean(goFile) == filepath.Clean(file)
+		}) {
+			continue
+		}
+		for obj, node := range pkg.declarations() {
+			start, end := artifact.fset.Position(node.Pos()), artifact.fset.Position(node.End())
+			if obj == nil || filepath.Clean(start.Filename) != filepath.Clean(file) || line < start.Line || line > end.Line {
+				continue
+			}
+			// the innermost declaration wins, the objects of the same declaration are ordered by name
+			if n := end.Line - start.Line; enclosing == nil || n < span || n == span && obj.Name() < enclosing.Name() {
+				enclosing, span = obj, n
+			}
+		}
+	}
+	return enclosing, enclosing != nil
+}
+
+// Lines returns the number of lines the declaration of the function spans, from the func keyword to the closing brace.
+// returns 0 for the functions without source, eg: interface methods
+func (f Function) Lines() int {
+	var node ast.Node = f.lit
+	if f.lit == nil {
+		decl := f.Decl()
+		if decl == nil {
+			return 0
+		}
+		node = decl
+	}
+	return f.artifact.fset.Position(node.End()).Line - f.artifact.fset.Position(node.Pos()).Line + 1
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kcmvp/archunit/internal/sample/flags"
 	"github.com/kcmvp/archunit/internal/sample/service"
 	_ "github.com/kcmvp/archunit/internal/sample/views"
 )
@@ -14,6 +15,13 @@ type LoginController struct {
 	userService service.UserService
 }
 
+func (l LoginController) firstName(ns service.NameService) string {
+	if impl, ok := ns.(service.NameServiceImpl); ok {
+		return impl.FirstNameI()
+	}
+	return ""
+}
+
 type CustomizeHandler func(c context.Context) error
 
 type AppContext struct {
@@ -41,7 +49,9 @@ func (a AppContext) Value(key any) any {
 }
 
 func LoginHandler() {
-	fmt.Println("for testing")
+	if flags.Enabled("login") {
+		fmt.Println("for testing")
+	}
 }
 
 var _ context.Context = (*AppContext)(nil)
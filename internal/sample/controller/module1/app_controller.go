@@ -2,6 +2,8 @@
 package module1
 
 import (
+	"net/http"
+
 	"github.com/kcmvp/archunit/internal/sample/repository"
 	v1 "github.com/kcmvp/archunit/internal/sample/service/ext/v1"
 )
@@ -16,3 +18,7 @@ func (a *AppController) firstName() {
 
 func (a AppController) lastName() {
 }
+
+func (a AppController) register(mux *http.ServeMux) {
+	mux.Handle("/app", http.NotFoundHandler())
+}
@@ -0,0 +1,10 @@
+// nolint
+package controller
+
+import "net/http"
+
+func Routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", http.NotFound)
+	return mux
+}
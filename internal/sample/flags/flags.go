@@ -0,0 +1,42 @@
+package flags
+
+import "sort"
+
+const debug = false
+
+type Client struct{}
+
+func (c Client) Enabled(flag string) bool {
+	if debug {
+		if flag == "" {
+			panic("flag name is required")
+		}
+	}
+	return false
+}
+
+func Enabled(flag string) bool {
+	return Client{}.Enabled(flag)
+}
+
+// Names returns the names of the enabled flags
+func Names(flags map[string]bool) []string {
+	var names []string
+	for name, enabled := range flags {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SortedNames returns the names of the enabled flags in alphabetical order
+func SortedNames(flags map[string]bool) (names []string) {
+	for name, enabled := range flags {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
@@ -1,3 +1,4 @@
+//archunit:stability=stable
 package model
 
 type User struct {
@@ -7,3 +7,13 @@ const (
 	F2
 	F3
 )
+
+func (f FF) String() string {
+	switch f {
+	case F1:
+		return "F1"
+	case F2:
+		return "F2"
+	}
+	return ""
+}
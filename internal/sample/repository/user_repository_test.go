@@ -0,0 +1,22 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/kcmvp/archunit/internal/sample/repository"
+)
+
+func TestFindUser(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	(repository.UserRepository{}).FindUser()
+}
+
+func TestFFString(t *testing.T) {
+	if repository.F1.String() != "F1" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,15 @@
+package service_test
+
+import (
+	"context"
+
+	"github.com/kcmvp/archunit/internal/sample/service"
+)
+
+func ExampleAuditCall() {
+	service.AuditCall("id", context.Background())
+}
+
+func ExampleUserService_GetUserById() {
+	_, _ = service.UserService{}.GetUserById("id")
+}
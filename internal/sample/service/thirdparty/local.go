@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+func ReadAll(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func OpenFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	return f, err
+}
+
+func FileSize(path string) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	stat, _ := f.Stat()
+	return stat.Size()
+}
+
+func bucket() string {
+	return os.Getenv("STORAGE_BUCKET")
+}
+
+func owner() int {
+	return syscall.Getuid()
+}
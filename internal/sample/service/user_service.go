@@ -3,17 +3,24 @@ package service
 
 import (
 	"context"
+	"github.com/kcmvp/archunit/internal/sample/flags"
 	"github.com/kcmvp/archunit/internal/sample/model"
 	"github.com/kcmvp/archunit/internal/sample/repository"
 )
 
+//archunit:stability=experimental
 type Audit func(string, context.Context) []string
 
+//archunit:stability=stable
 var auditLog Audit = func(s string, ctx context.Context) []string {
 	return []string{}
 }
 
+//archunit:stability=stable
 func AuditCall(id string, ctx context.Context) []string {
+	if flags.Enabled("audit") {
+		return []string{id}
+	}
 	return []string{}
 }
 
@@ -30,10 +37,12 @@ func (receiver UserService) GetUserById(id string) (model.User, error) {
 	panic("for test")
 }
 
+//archunit:ignore ExportedFunctionsShouldHaveTests until=2099-12-31
 func (receiver UserService) GetUserByNameAndAddress(name, address string) (model.User, error) {
 	panic("for test")
 }
 
+//archunit:ignore ExportedFunctionsShouldHaveTests until=2024-06-30
 func (receiver UserService) SearchUsersByFirstName(firstName string) ([]model.User, error) {
 	panic("for test")
 }
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAuditCall(t *testing.T) {
+	if len(AuditCall("", context.Background())) != 0 {
+		t.Fail()
+	}
+}
+
+func BenchmarkAuditCall(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		AuditCall("", context.Background())
+	}
+}
+
+func FuzzAuditCall(f *testing.F) {
+	f.Add("id")
+	f.Fuzz(func(t *testing.T, id string) {
+		if len(AuditCall(id, context.Background())) != 0 {
+			t.Fail()
+		}
+	})
+}
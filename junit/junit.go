@@ -0,0 +1,84 @@
+// Package junit reports the results of the architecture rules as JUnit XML, so the CI systems, eg: Jenkins or
+// GitLab, display every rule as a test case
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"io"
+	"strings"
+)
+
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// TestCase is the result of a rule, the class name is the category of the rule
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+}
+
+// Failure is the violations of a failed rule, one violation per line
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Reporter writes the results of the rules as JUnit XML
+type Reporter struct {
+	w io.Writer
+}
+
+// NewReporter creates the reporter writing to w, eg:
+// arch.ValidateWithReport(junit.NewReporter(file), rules...)
+func NewReporter(w io.Writer) *Reporter {
+	return &Reporter{w: w}
+}
+
+func (reporter *Reporter) Report(_ archunit.Architecture, results []archunit.RuleResult) error {
+	if _, err := io.WriteString(reporter.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(reporter.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(Convert(results)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(reporter.w, "\n")
+	return err
+}
+
+// Convert converts the results to a test suite named archunit, every rule is a test case
+func Convert(results []archunit.RuleResult) TestSuites {
+	suite := TestSuite{Name: "archunit", Tests: len(results)}
+	suite.TestCases = lo.Map(results, func(result archunit.RuleResult, _ int) TestCase {
+		testCase := TestCase{Name: result.Rule, ClassName: lo.If(result.Category != "", "archunit."+result.Category).Else("archunit")}
+		if result.Err != nil {
+			suite.Failures++
+			testCase.Failure = &Failure{
+				Message: fmt.Sprintf("%d violations", len(result.Violations)),
+				Type:    "violation",
+				Text: strings.Join(lo.Map(result.Violations, func(violation archunit.Violation, _ int) string {
+					return violation.Message
+				}), "\n"),
+			}
+		}
+		return testCase
+	})
+	return TestSuites{Tests: suite.Tests, Failures: suite.Failures, Suites: []TestSuite{suite}}
+}
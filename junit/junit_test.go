@@ -0,0 +1,33 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReporter(t *testing.T) {
+	naming := archunit.NewRule("naming", func(pkgs archunit.ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views").WithCategory("package")
+	passed := archunit.NewRule("passed", func(pkgs archunit.ArchPackage) error {
+		return nil
+	}, "...")
+	var buf bytes.Buffer
+	assert.Error(t, archunit.Project().ValidateWithReport(NewReporter(&buf), naming, passed))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+	var suites TestSuites
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	assert.Equal(t, 2, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Len(t, suites.Suites, 1)
+	cases := suites.Suites[0].TestCases
+	assert.Equal(t, "naming", cases[0].Name)
+	assert.Equal(t, "archunit.package", cases[0].ClassName)
+	assert.Equal(t, "1 violations", cases[0].Failure.Message)
+	assert.Contains(t, cases[0].Failure.Text, "archunit/internal/sample/views")
+	assert.Equal(t, TestCase{Name: "passed", ClassName: "archunit"}, cases[1])
+}
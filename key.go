@@ -0,0 +1,77 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+)
+
+// KeyFunctions is the functions taking string identifiers as keys, named as CallersOf. the key is the first argument
+// which is not a context.Context
+var KeyFunctions = lo.Flatten([][]string{
+	{"context.WithValue", "context.Context.Value"},
+	methodsOf("sync.Map", "Load", "Store", "LoadOrStore", "LoadAndDelete", "Delete", "Swap", "CompareAndSwap", "CompareAndDelete"),
+	methodsOf("net/http.Header", "Get", "Set", "Add", "Del", "Values"),
+	{"google.golang.org/grpc/metadata.Pairs"},
+	methodsOf("google.golang.org/grpc/metadata.MD", "Get", "Set", "Append", "Delete"),
+})
+
+// StringKeysShouldBeTypedConstants checks the keys passed to the functions, KeyFunctions by default, in the layer are
+// declared constants rather than raw string literals, and the keys of any type, eg: context values and sync.Map keys,
+// are constants of private named types so the keys of different packages never collide
+func (layer ArchLayer) StringKeysShouldBeTypedConstants(callPatterns ...string) error {
+	if len(callPatterns) == 0 {
+		callPatterns = KeyFunctions
+	}
+	var result Findings
+	lo.ForEach(layer, func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			if !funcMatches(site.Callee().Raw(), callPatterns...) {
+				return
+			}
+			if caller, ok := site.Caller(); ok && ignoredFunction("ArchLayer.StringKeysShouldBeTypedConstants", caller) {
+				return
+			}
+			params := site.Callee().Raw().Type().(*types.Signature).Params()
+			for i, arg := range site.Expr().Args {
+				param := params.At(min(i, params.Len()-1)).Type()
+				if slice, ok := param.(*types.Slice); ok && i >= params.Len()-1 && site.Callee().Raw().Type().(*types.Signature).Variadic() {
+					param = slice.Elem()
+				}
+				if named, ok := param.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context" {
+					continue
+				}
+				if basic, ok := info.TypeOf(arg).(*types.Basic); ok && basic.Info()&types.IsString != 0 && !declaredConstant(info, arg) {
+					result = append(result, violationAt(site.Position(), fmt.Sprintf("%s %s", callSite(site), types.ExprString(arg))))
+				} else if _, ok = param.Underlying().(*types.Interface); ok && !privateNamed(info.TypeOf(arg)) {
+					result = append(result, violationAt(site.Position(), fmt.Sprintf("%s %s", callSite(site), types.ExprString(arg))))
+				}
+				break
+			}
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("keys are not typed constants: %w"), result.sorted())).Else(nil)
+}
+
+func privateNamed(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && !named.Obj().Exported()
+}
+
+// declaredConstant checks whether the expression refers to a declared constant, eg: userKey or pkg.UserKey
+func declaredConstant(info *types.Info, expr ast.Expr) bool {
+	var id *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+	_, ok := info.Uses[id].(*types.Const)
+	return ok
+}
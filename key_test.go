@@ -0,0 +1,30 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestArchLayer_StringKeysShouldBeTypedConstants(t *testing.T) {
+	arch, err := Load("testdata/keys")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("keys")
+	assert.NoError(t, err)
+	err = ArchLayer(pkgs).StringKeysShouldBeTypedConstants()
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	expected := []string{
+		"example.com/keys.Raw -> (*sync.Map).Load \"user\"",
+		"example.com/keys.Raw -> (net/http.Header).Get \"X-Trace-Id\"",
+		"example.com/keys.Raw -> (context.Context).Value tenantKey",
+		"example.com/keys.Raw -> (net/http.Header).Del \"X-\" + Trace",
+		"example.com/keys.Raw -> (*sync.Map).Delete key",
+		"example.com/keys.Raw -> context.WithValue \"user\"",
+	}
+	assert.Len(t, violations, len(expected))
+	for i, v := range expected {
+		assert.True(t, strings.HasSuffix(violations[i], v), violations[i])
+	}
+	assert.NoError(t, ArchLayer(pkgs).StringKeysShouldBeTypedConstants("sync.Map.Store"))
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"github.com/kcmvp/archunit/internal"
 	"github.com/samber/lo"
+	"go/types"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -17,6 +20,15 @@ const (
 	Private
 )
 
+// CategoryDependency is the category of the rules on the imports of the packages, eg: ShouldNotImport
+const CategoryDependency = "dependency"
+
+// DefaultLocale is the locale of the built-in messages, it needs no catalog
+const DefaultLocale = "en"
+
+// layerName is the full name of the function Layer
+const layerName = "github.com/kcmvp/archunit.Layer"
+
 type NamePattern func(name, arg string) bool
 
 func BeLowerCase(name, _ string) bool {
@@ -35,36 +47,58 @@ func HaveSuffix(name, suffix string) bool {
 type ArchLayer []*internal.Package
 
 func SourceNameShould(pattern NamePattern, args ...string) error {
-	if file, ok := lo.Find(internal.Arch().GoFiles(), func(file string) bool {
+	return Project().SourceNameShould(pattern, args...)
+}
+
+// SourceNameShould checks the names of the source files of the architecture match the pattern
+func (arch Architecture) SourceNameShould(pattern NamePattern, args ...string) error {
+	if file, ok := lo.Find(arch.artifact.GoFiles(), func(file string) bool {
 		return !pattern(filepath.Base(file), lo.If(args == nil, "").ElseF(func() string {
 			return args[0]
 		}))
 	}); ok {
-		return fmt.Errorf("file %s's name breaks the rule", file)
+		return fmt.Errorf(localize("file %s's name breaks the rule"), file)
 	}
 	return nil
 }
 
 func ConstantsShouldBeDefinedInOneFileByPackage() error {
-	for _, pkg := range internal.Arch().Packages() {
+	return Project().ConstantsShouldBeDefinedInOneFileByPackage()
+}
+
+// ConstantsShouldBeDefinedInOneFileByPackage checks the constants of every package of the architecture are defined in
+// one file
+func (arch Architecture) ConstantsShouldBeDefinedInOneFileByPackage() error {
+	return ArchPackage(arch.artifact.Packages()).ConstantsShouldBeDefinedInOneFile()
+}
+
+// ConstantsShouldBeDefinedInOneFile checks the constants of every package of the selection are defined in one file
+func (archPkg ArchPackage) ConstantsShouldBeDefinedInOneFile() error {
+	for _, pkg := range archPkg {
 		files := pkg.ConstantFiles()
 		if len(files) > 1 {
-			return fmt.Errorf("package %s constants are definied in files %v", pkg.ID(), files)
+			return fmt.Errorf(localize("package %s constants are definied in files %v"), pkg.ID(), files)
 		}
 	}
 	return nil
 }
 
 func Layer(pkgPaths ...string) (ArchLayer, error) {
-	patterns, err := ScopePattern(pkgPaths...)
+	return Project().Layer(pkgPaths...)
+}
+
+// Layer returns the layer of the packages of the architecture which match the paths
+func (arch Architecture) Layer(pkgPaths ...string) (ArchLayer, error) {
+	pkgs, err := arch.Packages(pkgPaths...)
 	if err != nil {
 		return nil, err
 	}
-	return lo.Filter(internal.Arch().Packages(), func(pkg *internal.Package, _ int) bool {
-		return lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
-			return pattern.MatchString(pkg.ID())
-		})
-	}), nil
+	return ArchLayer(pkgs), nil
+}
+
+// layer returns the layer of the paths in the architecture the layer belongs to
+func (layer ArchLayer) layer(pkgPaths ...string) (ArchLayer, error) {
+	return ArchPackage(layer).architecture().Layer(pkgPaths...)
 }
 
 func (layer ArchLayer) Name() string {
@@ -169,11 +203,11 @@ func (layer ArchLayer) ShouldNotReferLayers(layers ...ArchLayer) error {
 	path, ok := lo.Find(layer.Imports(), func(ref string) bool {
 		return lo.Contains(packages, ref)
 	})
-	return lo.If(ok, fmt.Errorf("%s refers %s", layer.Name(), path)).Else(nil)
+	return lo.If(ok, fmt.Errorf(localize("%s refers %s"), layer.Name(), path)).Else(nil)
 }
 
 func (layer ArchLayer) ShouldNotReferPackages(paths ...string) error {
-	l, err := Layer(paths...)
+	l, err := layer.layer(paths...)
 	if err != nil {
 		return err
 	}
@@ -186,17 +220,41 @@ func (layer ArchLayer) ShouldOnlyReferLayers(layers ...ArchLayer) error {
 		pkgs = append(pkgs, l.packages()...)
 	}
 	d1, _ := lo.Difference(layer.Imports(), pkgs)
-	return lo.If(len(d1) > 0, fmt.Errorf("%v are out of scope %v", d1, pkgs)).Else(nil)
+	return lo.If(len(d1) > 0, fmt.Errorf(localize("%w are out of scope %v"), Violations(d1), pkgs)).Else(nil)
 }
 
 func (layer ArchLayer) ShouldOnlyReferPackages(paths ...string) error {
-	l, err := Layer(paths...)
+	l, err := layer.layer(paths...)
 	if err != nil {
 		return err
 	}
 	return layer.ShouldOnlyReferLayers(l)
 }
 
+// ShouldOnlyReferListedIn checks the imports of the layer are all listed in the whitelist file, one import pattern per
+// line, blank lines and lines start with # are ignored. `...` in a pattern matches any string and `*` matches any
+// characters of one path segment, eg: github.com/samber/... or golang.org/x/*/cover
+func (layer ArchLayer) ShouldOnlyReferListedIn(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var patterns []*regexp.Regexp
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			pattern := strings.ReplaceAll(strings.ReplaceAll(regexp.QuoteMeta(line), `\.\.\.`, ".*"), `\*`, "[^/]*")
+			patterns = append(patterns, regexp.MustCompile(fmt.Sprintf("^%s$", pattern)))
+		}
+	}
+	unlisted := lo.Uniq(lo.Filter(layer.Imports(), func(ref string, _ int) bool {
+		return lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(ref)
+		})
+	}))
+	sort.Strings(unlisted)
+	return lo.If(len(unlisted) > 0, fmt.Errorf(localize("%w are not listed in %s"), Violations(unlisted), file)).Else(nil)
+}
+
 func (layer ArchLayer) ShouldBeOnlyReferredByLayers(layers ...ArchLayer) error {
 	return ArchPackage(layer).ShouldBeOnlyReferredByPackages(lo.Map(layers, func(item ArchLayer, _ int) ArchPackage {
 		return ArchPackage(item)
@@ -204,7 +262,7 @@ func (layer ArchLayer) ShouldBeOnlyReferredByLayers(layers ...ArchLayer) error {
 }
 
 func (layer ArchLayer) ShouldBeOnlyReferredByPackages(paths ...string) error {
-	l, err := Layer(paths...)
+	l, err := layer.layer(paths...)
 	if err != nil {
 		return err
 	}
@@ -216,7 +274,103 @@ func (layer ArchLayer) DepthShouldLessThan(depth int) error {
 		return len(strings.Split(a.ID(), "/")) > len(strings.Split(a.ID(), "/"))
 	})
 	if acc := len(strings.Split(pkg.ID(), "/")); acc >= depth {
-		return fmt.Errorf("%s max depth is %d", pkg.ID(), acc)
+		return fmt.Errorf(localize("%s max depth is %d"), pkg.ID(), acc)
 	}
 	return nil
 }
+
+// ShouldNotExposeTypesFrom checks the exported API of the layer, signatures of exported functions and methods,
+// exported fields and exported variables, does not refer to the types of the specified modules or packages,
+// eg: github.com/gin-gonic/gin or gorm.io/gorm
+func (layer ArchLayer) ShouldNotExposeTypesFrom(modules ...string) error {
+	var result []string
+	expose := func(name string, typ types.Type) {
+		lo.ForEach(referredTypes(typ, map[types.Type]bool{}), func(named *types.Named, _ int) {
+			if pkg := named.Obj().Pkg(); pkg != nil && lo.SomeBy(modules, func(module string) bool {
+				return pkg.Path() == module || strings.HasPrefix(pkg.Path(), module+"/")
+			}) {
+				result = append(result, fmt.Sprintf("%s exposes %s.%s", name, pkg.Path(), named.Obj().Name()))
+			}
+		})
+	}
+	lo.ForEach(layer, func(pkg *internal.Package, _ int) {
+		scope := pkg.Raw().Types.Scope()
+		lo.ForEach(scope.Names(), func(name string, _ int) {
+			obj := scope.Lookup(name)
+			if !obj.Exported() {
+				return
+			}
+			switch o := obj.(type) {
+			case *types.Func, *types.Var:
+				expose(qualifiedName(o), o.Type())
+			case *types.TypeName:
+				named, ok := o.Type().(*types.Named)
+				if !ok {
+					return
+				}
+				switch underlying := named.Underlying().(type) {
+				case *types.Struct:
+					for i := 0; i < underlying.NumFields(); i++ {
+						if field := underlying.Field(i); field.Exported() {
+							expose(fmt.Sprintf("%s.%s", qualifiedName(o), field.Name()), field.Type())
+						}
+					}
+				case *types.Interface:
+					for i := 0; i < underlying.NumExplicitMethods(); i++ {
+						expose(underlying.ExplicitMethod(i).FullName(), underlying.ExplicitMethod(i).Type())
+					}
+				default:
+					expose(qualifiedName(o), underlying)
+				}
+				for i := 0; i < named.NumMethods(); i++ {
+					if method := named.Method(i); method.Exported() {
+						expose(method.FullName(), method.Type())
+					}
+				}
+			}
+		})
+	})
+	result = lo.Uniq(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("%s exposes types of %v: %w"), layer.Name(), modules, Violations(result))).Else(nil)
+}
+
+// referredTypes returns the named types referred by the type, the underlying types of the named types are not visited
+func referredTypes(typ types.Type, seen map[types.Type]bool) []*types.Named {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+	var referred []*types.Named
+	switch t := typ.(type) {
+	case *types.Named:
+		referred = append(referred, t)
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			referred = append(referred, referredTypes(t.TypeArgs().At(i), seen)...)
+		}
+	case *types.Pointer:
+		referred = referredTypes(t.Elem(), seen)
+	case *types.Slice:
+		referred = referredTypes(t.Elem(), seen)
+	case *types.Array:
+		referred = referredTypes(t.Elem(), seen)
+	case *types.Chan:
+		referred = referredTypes(t.Elem(), seen)
+	case *types.Map:
+		referred = append(referredTypes(t.Key(), seen), referredTypes(t.Elem(), seen)...)
+	case *types.Signature:
+		referred = append(referredTypes(t.Params(), seen), referredTypes(t.Results(), seen)...)
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			referred = append(referred, referredTypes(t.At(i).Type(), seen)...)
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			referred = append(referred, referredTypes(t.Field(i).Type(), seen)...)
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			referred = append(referred, referredTypes(t.Method(i).Type(), seen)...)
+		}
+	}
+	return referred
+}
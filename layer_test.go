@@ -4,6 +4,8 @@ import (
 	"github.com/kcmvp/archunit/internal"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -25,8 +27,8 @@ func TestLayerPackages(t *testing.T) {
 			name:   "sample and sub Layer",
 			paths:  []string{".../internal/sample/..."},
 			except: []string{".../ext"},
-			size1:  12,
-			size2:  10,
+			size1:  13,
+			size2:  11,
 		},
 		{
 			name:  "ext",
@@ -105,9 +107,12 @@ func TestLayPackages(t *testing.T) {
 			"github.com/kcmvp/archunit/internal/sample/views",
 			"github.com/kcmvp/archunit/internal/sample/repository",
 			"github.com/kcmvp/archunit/internal/sample/service/ext/v1",
+			"github.com/kcmvp/archunit/internal/sample/flags",
 			"fmt",
 			"time",
 			"context",
+			"net/http",
+			"net/http",
 		})
 }
 
@@ -136,5 +141,40 @@ func TestLayer_Refer(t *testing.T) {
 	})
 	assert.ElementsMatch(t, lo.Map(controller.Functions(), func(item internal.Function, index int) string {
 		return item.Name()
-	}), []string{"LoginHandler"})
+	}), []string{"LoginHandler", "Routes"})
+}
+
+func TestLayer_ShouldNotExposeTypesFrom(t *testing.T) {
+	controller, _ := Layer("sample/controller", "sample/controller/...")
+	err := controller.ShouldNotExposeTypesFrom("context")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "github.com/kcmvp/archunit/internal/sample/controller.CustomizeHandler exposes context.Context")
+	assert.Contains(t, err.Error(), "github.com/kcmvp/archunit/internal/sample/controller.AppContext.Context exposes context.Context")
+	assert.NoError(t, controller.ShouldNotExposeTypesFrom("net/url", "github.com/gin-gonic"))
+	views, _ := Layer("sample/views")
+	err = views.ShouldNotExposeTypesFrom("net")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/views.UserView.Seq exposes net/url.URL")
+	assert.NoError(t, views.ShouldNotExposeTypesFrom("net/http"))
+	service, _ := Layer("sample/service")
+	err = service.ShouldNotExposeTypesFrom("context")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/service.AuditCall exposes context.Context")
+	assert.Contains(t, err.Error(), "sample/service.Audit exposes context.Context")
+	assert.NotContains(t, err.Error(), "auditLog")
+	model, _ := Layer("sample/model")
+	assert.NoError(t, model.ShouldNotExposeTypesFrom("context", "github.com/kcmvp/archunit"))
+}
+
+func TestLayer_ShouldOnlyReferListedIn(t *testing.T) {
+	service, _ := Layer("sample/service/...")
+	err := service.ShouldOnlyReferListedIn("testdata/deps-allow.txt")
+	assert.Error(t, err)
+	assert.Equal(t, "[io os syscall] are not listed in testdata/deps-allow.txt", err.Error())
+	allow := filepath.Join(t.TempDir(), "deps-allow.txt")
+	assert.NoError(t, os.WriteFile(allow, []byte("github.com/kcmvp/archunit/internal/sample/...\ncontext\n*\n"), 0o644))
+	assert.NoError(t, service.ShouldOnlyReferListedIn(allow))
+	model, _ := Layer("sample/model")
+	assert.NoError(t, model.ShouldOnlyReferListedIn("testdata/deps-allow.txt"))
+	assert.Error(t, service.ShouldOnlyReferListedIn("testdata/absent.txt"))
 }
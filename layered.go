@@ -0,0 +1,183 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+)
+
+// LayeredArchitecture is the layers ordered from the top to the bottom, a layer may only access the layers beneath it.
+// it is relaxed by default, which means a layer may access any lower layer
+type LayeredArchitecture struct {
+	layers      []ArchLayer
+	unskippable []int
+	definitions []layerDefinition
+	rules       []Rule
+}
+
+// layerDefinition is a layer named by Layer, it is resolved by the architecture the rules validate against
+type layerDefinition struct {
+	name  string
+	paths []string
+}
+
+// LayerConstraint is the access constraint of the layer named by WhereLayer
+type LayerConstraint struct {
+	arch  LayeredArchitecture
+	layer string
+}
+
+// Layers creates the relaxed layered architecture, the layers are ordered from the top to the bottom, eg:
+// Layers(controller, service, repository)
+func Layers(layers ...ArchLayer) LayeredArchitecture {
+	return LayeredArchitecture{layers: layers}
+}
+
+// Strict requires every layer only accesses the layer immediately beneath it
+func (arch LayeredArchitecture) Strict() LayeredArchitecture {
+	arch.unskippable = lo.Range(len(arch.layers))
+	return arch
+}
+
+// Relaxed allows every layer to access any lower layer
+func (arch LayeredArchitecture) Relaxed() LayeredArchitecture {
+	arch.unskippable = nil
+	return arch
+}
+
+// AllowSkipping allows the layers to skip the lower layers except the specified ones, the upper layers must access
+// the layers beneath an excepted layer through it. eg: Layers(controller, service, repository, model).AllowSkipping(service)
+// forbids controller from accessing repository and model, while service may access model directly
+func (arch LayeredArchitecture) AllowSkipping(except ...ArchLayer) LayeredArchitecture {
+	names := lo.Map(except, func(layer ArchLayer, _ int) string {
+		return layer.Name()
+	})
+	arch.unskippable = lo.FilterMap(arch.layers, func(layer ArchLayer, i int) (int, bool) {
+		return i, lo.Contains(names, layer.Name())
+	})
+	return arch
+}
+
+// Validate checks the dependencies between the layers follow the access mode of the architecture
+func (arch LayeredArchitecture) Validate() error {
+	level := map[string]int{}
+	lo.ForEach(arch.layers, func(layer ArchLayer, i int) {
+		lo.ForEach(layer.packages(), func(pkg string, _ int) {
+			level[pkg] = i
+		})
+	})
+	var result []string
+	lo.ForEach(arch.layers, func(layer ArchLayer, i int) {
+		lo.ForEach(layer, func(pkg *internal.Package, _ int) {
+			lo.ForEach(pkg.Imports(), func(ref string, _ int) {
+				j, ok := level[ref]
+				if !ok || j == i {
+					return
+				}
+				if j < i {
+					result = append(result, fmt.Sprintf(localize("%s refers upper layer %s"), pkg.ID(), ref))
+				} else if skipped, ok := lo.Find(arch.unskippable, func(k int) bool {
+					return i < k && k < j
+				}); ok {
+					result = append(result, fmt.Sprintf(localize("%s refers %s skipping %s"), pkg.ID(), ref, arch.layers[skipped].Name()))
+				}
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("layers are violated: %w"), Violations(result))).Else(nil)
+}
+
+// Layer defines the layer of the package paths by name, the named layers are constrained by WhereLayer and compiled
+// into the rules of Architecture.Validate, eg:
+// Layers().Layer("controller", "sample/controller/...").Layer("service", "sample/service/...").
+// WhereLayer("service").MayOnlyBeAccessedByLayers("controller").Rules()
+func (arch LayeredArchitecture) Layer(name string, paths ...string) LayeredArchitecture {
+	arch.definitions = append(arch.definitions[:len(arch.definitions):len(arch.definitions)], layerDefinition{name: name, paths: paths})
+	return arch
+}
+
+// WhereLayer starts the constraint of the named layer
+func (arch LayeredArchitecture) WhereLayer(name string) LayerConstraint {
+	return LayerConstraint{arch: arch, layer: name}
+}
+
+// Rules returns the rules compiled from the constraints of the named layers
+func (arch LayeredArchitecture) Rules() []Rule {
+	return arch.rules
+}
+
+// MayOnlyBeAccessedByLayers constrains the layer is only imported by the packages of the named layers
+func (constraint LayerConstraint) MayOnlyBeAccessedByLayers(names ...string) LayeredArchitecture {
+	return constraint.rule(fmt.Sprintf("layer %s may only be accessed by layers %v", constraint.layer, names), accessedBy, names...)
+}
+
+// MayNotBeAccessedByAnyLayer constrains the layer is not imported by any package out of it
+func (constraint LayerConstraint) MayNotBeAccessedByAnyLayer() LayeredArchitecture {
+	return constraint.rule(fmt.Sprintf("layer %s may not be accessed by any layer", constraint.layer), accessedBy)
+}
+
+// MayOnlyAccessLayers constrains the layer only imports the named layers among the defined layers, the imports of
+// the packages out of the defined layers are not constrained, eg: the standard library
+func (constraint LayerConstraint) MayOnlyAccessLayers(names ...string) LayeredArchitecture {
+	return constraint.rule(fmt.Sprintf("layer %s may only access layers %v", constraint.layer, names), func(layer, others ArchPackage) []string {
+		var defined []string
+		for _, definition := range constraint.arch.definitions {
+			pkgs, _ := layer.architecture().Packages(definition.paths...)
+			defined = append(defined, pkgs.ID()...)
+		}
+		var result []string
+		lo.ForEach(layer, func(pkg *internal.Package, _ int) {
+			lo.ForEach(pkg.Imports(), func(ref string, _ int) {
+				if lo.Contains(defined, ref) && !lo.Contains(layer.ID(), ref) && !lo.Contains(others.ID(), ref) {
+					result = append(result, fmt.Sprintf(localize("%s refers %s"), pkg.ID(), ref))
+				}
+			})
+		})
+		return result
+	}, names...)
+}
+
+// rule compiles the check of the layer against the named layers into a rule of the layer paths
+func (constraint LayerConstraint) rule(name string, check func(layer, others ArchPackage) []string, names ...string) LayeredArchitecture {
+	arch := constraint.arch
+	definition, ok := lo.Find(arch.definitions, func(definition layerDefinition) bool {
+		return definition.name == constraint.layer
+	})
+	rule := NewRule(name, func(layer ArchPackage) error {
+		if !ok {
+			return fmt.Errorf(localize("layer %s is not defined"), constraint.layer)
+		}
+		var paths []string
+		for _, other := range names {
+			found, ok := lo.Find(arch.definitions, func(definition layerDefinition) bool {
+				return definition.name == other
+			})
+			if !ok {
+				return fmt.Errorf(localize("layer %s is not defined"), other)
+			}
+			paths = append(paths, found.paths...)
+		}
+		others, err := layer.architecture().Packages(paths...)
+		if err != nil {
+			return err
+		}
+		result := check(layer, others)
+		return lo.If(len(result) > 0, fmt.Errorf(localize("layer %s is violated: %w"), constraint.layer, Violations(result))).Else(nil)
+	}, definition.paths...)
+	arch.rules = append(arch.rules[:len(arch.rules):len(arch.rules)], rule)
+	return arch
+}
+
+// accessedBy returns the imports of the layer by the packages out of the layer and the other layers
+func accessedBy(layer, others ArchPackage) []string {
+	var result []string
+	lo.ForEach(layer.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		if lo.Contains(layer, pkg) || lo.Contains(others, pkg) {
+			return
+		}
+		lo.ForEach(lo.Intersect(pkg.Imports(), layer.ID()), func(ref string, _ int) {
+			result = append(result, fmt.Sprintf(localize("%s refers %s"), pkg.ID(), ref))
+		})
+	})
+	return result
+}
@@ -0,0 +1,63 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLayeredArchitecture(t *testing.T) {
+	controller, _ := Layer("sample/controller/...")
+	service, _ := Layer("sample/service/...")
+	repository, _ := Layer("sample/repository/...")
+	model, _ := Layer("sample/model")
+	layers := Layers(controller, service, repository, model)
+	assert.NoError(t, layers.Validate())
+	assert.NoError(t, layers.Strict().Relaxed().Validate())
+	err := layers.Strict().Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/controller/module1 refers github.com/kcmvp/archunit/internal/sample/repository skipping")
+	assert.Contains(t, err.Error(), "sample/service refers github.com/kcmvp/archunit/internal/sample/model skipping")
+	err = layers.AllowSkipping(service).Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/controller/module1 refers github.com/kcmvp/archunit/internal/sample/repository skipping")
+	assert.NotContains(t, err.Error(), "sample/service refers")
+	err = layers.AllowSkipping(repository).Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/service refers github.com/kcmvp/archunit/internal/sample/model skipping")
+	assert.NotContains(t, err.Error(), "sample/controller/module1 refers")
+	assert.NoError(t, layers.AllowSkipping(model).Validate())
+	err = Layers(model, repository).Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/repository refers upper layer github.com/kcmvp/archunit/internal/sample/model")
+}
+
+func TestLayeredArchitecture_Rules(t *testing.T) {
+	layers := Layers().
+		Layer("controller", "sample/controller/...").
+		Layer("service", "sample/service/...").
+		Layer("repository", "sample/repository/...").
+		Layer("model", "sample/model")
+	rules := layers.WhereLayer("controller").MayNotBeAccessedByAnyLayer().
+		WhereLayer("service").MayOnlyBeAccessedByLayers("controller").
+		WhereLayer("repository").MayOnlyAccessLayers("model").
+		Rules()
+	assert.Len(t, rules, 3)
+	assert.Equal(t, []string{
+		"layer controller may not be accessed by any layer",
+		"layer service may only be accessed by layers [controller]",
+		"layer repository may only access layers [model]",
+	}, lo.Map(rules, func(rule Rule, _ int) string {
+		return rule.Name()
+	}))
+	assert.NoError(t, Project().Validate(rules...))
+	err := Project().Validate(layers.WhereLayer("model").MayOnlyBeAccessedByLayers("repository").Rules()...)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Contains(t, violations, "github.com/kcmvp/archunit/internal/sample/service refers github.com/kcmvp/archunit/internal/sample/model")
+	err = Project().Validate(layers.WhereLayer("controller").MayOnlyAccessLayers("service").Rules()...)
+	assert.ErrorAs(t, err, &violations)
+	assert.Contains(t, violations, "github.com/kcmvp/archunit/internal/sample/controller/module1 refers github.com/kcmvp/archunit/internal/sample/repository")
+	err = Project().Validate(layers.WhereLayer("view").MayNotBeAccessedByAnyLayer().Rules()...)
+	assert.EqualError(t, err, "layer view may not be accessed by any layer: layer view is not defined")
+}
@@ -0,0 +1,65 @@
+package archunit
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MessageCatalog translates the message formats of the built-in rules into a language. the formats are keyed by the
+// english ones, eg: "constants are not exported: %w", and the translations must keep the verbs of the english formats
+// in the same order
+type MessageCatalog interface {
+	Translate(format string) (string, bool)
+}
+
+// Messages is the message catalog of the translations keyed by the english formats
+type Messages map[string]string
+
+func (messages Messages) Translate(format string) (string, bool) {
+	translation, ok := messages[format]
+	return translation, ok
+}
+
+var (
+	localeMu sync.RWMutex
+	catalogs = map[string]MessageCatalog{}
+	locale   = DefaultLocale
+)
+
+// RegisterLocale registers the message catalog of the locale, eg: RegisterLocale("de", Messages{...})
+func RegisterLocale(name string, catalog MessageCatalog) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	catalogs[name] = catalog
+}
+
+// UseLocale reports the violations of the built-in rules in the messages of the locale, the formats missing from the
+// catalog of the locale are reported in english. it returns an error when the locale is not registered
+func UseLocale(name string) error {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	if _, ok := catalogs[name]; !ok && name != DefaultLocale {
+		return fmt.Errorf("locale %s is not registered", name)
+	}
+	locale = name
+	return nil
+}
+
+// Locale returns the locale of the messages of the built-in rules
+func Locale() string {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	return locale
+}
+
+// localize returns the format of the current locale
+func localize(format string) string {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	if catalog, ok := catalogs[locale]; ok {
+		if translation, ok := catalog.Translate(format); ok {
+			return translation
+		}
+	}
+	return format
+}
@@ -0,0 +1,31 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUseLocale(t *testing.T) {
+	t.Cleanup(func() {
+		_ = UseLocale(DefaultLocale)
+	})
+	assert.Equal(t, "en", Locale())
+	assert.EqualError(t, UseLocale("fr"), "locale fr is not registered")
+	RegisterLocale("de", Messages{
+		"constants are not exported: %w": "Konstanten sind nicht exportiert: %w",
+		"%s exports %d symbols %v":       "%s exportiert %d Symbole %v",
+	})
+	assert.NoError(t, UseLocale("de"))
+	assert.Equal(t, "de", Locale())
+	pkgs, _ := Packages("sample/flags")
+	err := pkgs.Constants().ShouldBeExported()
+	assert.EqualError(t, err, "Konstanten sind nicht exportiert: [github.com/kcmvp/archunit/internal/sample/flags.debug]")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	err = pkgs.Constants().NameShould(BeUpperCase)
+	assert.EqualError(t, err, "constant names break the rule: [github.com/kcmvp/archunit/internal/sample/flags.debug]")
+	// the violations are translated as well as the rules
+	assert.ErrorContains(t, PackagesShouldExportAtMost(0, pkgs), "github.com/kcmvp/archunit/internal/sample/flags exportiert 4 Symbole [Client Enabled Names SortedNames]")
+	assert.NoError(t, UseLocale(DefaultLocale))
+	assert.EqualError(t, pkgs.Constants().ShouldBeExported(), "constants are not exported: [github.com/kcmvp/archunit/internal/sample/flags.debug]")
+}
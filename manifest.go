@@ -0,0 +1,123 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// manifest is the architecture manifest, eg:
+//
+//	layers:
+//	  controller:
+//	    - sample/controller/...
+type manifest struct {
+	Layers map[string][]string `yaml:"layers"`
+}
+
+// LayersShouldMatchManifest checks the layers declared in the code of the project, the calls of Layer with string literals
+// including the ones in the tests, are the same as the layers of the manifest file relative to the project root.
+// layers are compared by their paths, a layer declared in the code but not in the manifest and a layer in the
+// manifest but not declared in the code are both reported
+func LayersShouldMatchManifest(path string) error {
+	return Project().LayersShouldMatchManifest(path)
+}
+
+// LayersShouldMatchManifest checks the layers declared in the code of the architecture, the manifest file is relative
+// to the root of the architecture
+func (arch Architecture) LayersShouldMatchManifest(path string) error {
+	data, err := os.ReadFile(filepath.Join(arch.RootDir(), path))
+	if err != nil {
+		return err
+	}
+	var m manifest
+	if err = yaml.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf(localize("can not parse %s: %w"), path, err)
+	}
+	declared := map[string]token.Position{}
+	declare := func(pkg *packages.Package, file *ast.File) {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if paths, ok := layerPaths(pkg.TypesInfo, node); ok {
+				if _, exists := declared[layerKey(paths)]; !exists {
+					declared[layerKey(paths)] = pkg.Fset.Position(node.Pos())
+				}
+			}
+			return true
+		})
+	}
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			declare(pkg.Raw(), file)
+		})
+		// the internal test package includes the files of the package as well
+		lo.ForEach(pkg.TestPackages(), func(testPkg *packages.Package, _ int) {
+			lo.ForEach(testPkg.Syntax, func(file *ast.File, _ int) {
+				if strings.HasSuffix(testPkg.Fset.Position(file.Pos()).Filename, "_test.go") {
+					declare(testPkg, file)
+				}
+			})
+		})
+	})
+	manifested := map[string]string{}
+	for name, paths := range m.Layers {
+		manifested[layerKey(paths)] = name
+	}
+	var result Findings
+	for key, position := range declared {
+		if _, ok := manifested[key]; !ok {
+			result = append(result, violationAt(position, fmt.Sprintf(localize("layer %s at %s is not in the manifest"), key, position)))
+		}
+	}
+	for key, name := range manifested {
+		if _, ok := declared[key]; !ok {
+			result = append(result, Violation{Message: fmt.Sprintf(localize("layer %s %s is not declared in the code"), name, key)})
+		}
+	}
+	return lo.If(len(result) > 0, fmt.Errorf(localize("layers drift from %s: %w"), path, result.sorted())).Else(nil)
+}
+
+// layerPaths returns the paths of the call of Layer when all the arguments are string literals, the callee is resolved
+// by the type information so the functions named Layer in the other packages are not layer declarations
+func layerPaths(info *types.Info, node ast.Node) ([]string, bool) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 || info == nil {
+		return nil, false
+	}
+	var callee *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		callee = fun
+	case *ast.SelectorExpr:
+		callee = fun.Sel
+	default:
+		return nil, false
+	}
+	fn, ok := info.Uses[callee].(*types.Func)
+	ok = ok && fn.FullName() == layerName
+	var paths []string
+	for _, arg := range call.Args {
+		lit, isLit := arg.(*ast.BasicLit)
+		if !isLit || lit.Kind != token.STRING {
+			return nil, false
+		}
+		path, _ := strconv.Unquote(lit.Value)
+		paths = append(paths, path)
+	}
+	return paths, ok
+}
+
+func layerKey(paths []string) string {
+	paths = lo.Uniq(paths)
+	sort.Strings(paths)
+	return fmt.Sprintf("[%s]", strings.Join(paths, " "))
+}
@@ -0,0 +1,23 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLayersShouldMatchManifest(t *testing.T) {
+	err := LayersShouldMatchManifest("testdata/architecture.yaml")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Contains(t, violations, "layer legacy [sample/legacy/...] is not declared in the code")
+	assert.True(t, lo.SomeBy(violations, func(v string) bool {
+		return strings.HasPrefix(v, "layer [sample/service/...] at ") && strings.HasSuffix(v, " is not in the manifest")
+	}))
+	assert.False(t, lo.SomeBy(violations, func(v string) bool {
+		return strings.HasPrefix(v, "layer [sample/model] ") || strings.HasPrefix(v, "layer controller ")
+	}))
+	assert.Error(t, LayersShouldMatchManifest("testdata/absent.yaml"))
+	assert.Error(t, LayersShouldMatchManifest("testdata/deps-allow.txt"))
+}
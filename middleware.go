@@ -0,0 +1,137 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MiddlewareChain is the middlewares registered by the sequence of Use(...) calls on one router in a function
+type MiddlewareChain struct {
+	Router      string
+	Middlewares []string
+}
+
+// MiddlewareChains is the middleware chains of the router packages
+type MiddlewareChains []MiddlewareChain
+
+// MiddlewareChains extracts the middleware chains of the packages, the calls of the Use methods on the same router
+// variable in the same function make one chain. middlewares are named as CallersOf, a middleware returned by a
+// factory call, eg: gin.Recovery(), is named after the factory
+func (archPkg ArchPackage) MiddlewareChains() MiddlewareChains {
+	var chains MiddlewareChains
+	index := map[string]int{}
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		// the outer call of the chained calls, eg: r.Use(a).Use(b), is visited first, so the sites are ordered by
+		// the positions of their arguments
+		sites := append([]internal.CallSite{}, pkg.CallSites()...)
+		sort.SliceStable(sites, func(i, j int) bool {
+			return sites[i].Expr().Lparen < sites[j].Expr().Lparen
+		})
+		lo.ForEach(sites, func(site internal.CallSite, _ int) {
+			sel, ok := site.Expr().Fun.(*ast.SelectorExpr)
+			if site.Callee().Name() != "Use" || !ok || site.Callee().Raw().Type().(*types.Signature).Recv() == nil {
+				return
+			}
+			router := fmt.Sprintf("%s %s", callerName(site), types.ExprString(useReceiver(sel.X)))
+			i, ok := index[router]
+			if !ok {
+				i = len(chains)
+				index[router] = i
+				chains = append(chains, MiddlewareChain{Router: router})
+			}
+			chains[i].Middlewares = append(chains[i].Middlewares, lo.Map(site.Expr().Args, func(arg ast.Expr, _ int) string {
+				return middlewareName(arg, info)
+			})...)
+		})
+	})
+	return chains
+}
+
+// useReceiver returns the router of the chained Use calls, eg: r of r.Use(a).Use(b)
+func useReceiver(expr ast.Expr) ast.Expr {
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return expr
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Use" {
+			return expr
+		}
+		expr = sel.X
+	}
+}
+
+func middlewareName(arg ast.Expr, info *types.Info) string {
+	expr := ast.Unparen(arg)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		expr = ast.Unparen(call.Fun)
+	}
+	var id *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	}
+	if id != nil {
+		if f, ok := info.Uses[id].(*types.Func); ok {
+			return funcName(f)
+		}
+	}
+	return types.ExprString(arg)
+}
+
+// ShouldBeInOrder checks every chain registers the middlewares matching the regular expressions and in the same order
+// as the expressions, eg: ShouldBeInOrder("Recovery$", "Auth$") requires recovery before authentication
+func (chains MiddlewareChains) ShouldBeInOrder(patterns ...string) error {
+	return chains.inOrder(false, patterns)
+}
+
+// ShouldStartWith checks every chain registers the middlewares matching the regular expressions in the same order as
+// ShouldBeInOrder, and the first middleware of the chain matches the first expression, eg: ShouldStartWith("Recovery$")
+// requires recovery to be the first middleware so it recovers the panics of all the others
+func (chains MiddlewareChains) ShouldStartWith(patterns ...string) error {
+	return chains.inOrder(true, patterns)
+}
+
+func (chains MiddlewareChains) inOrder(anchored bool, patterns []string) error {
+	var exps []*regexp.Regexp
+	for _, pattern := range patterns {
+		reg, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		exps = append(exps, reg)
+	}
+	var result []string
+	lo.ForEach(chains, func(chain MiddlewareChain, _ int) {
+		last := -1
+		for i, reg := range exps {
+			_, at, found := lo.FindIndexOf(chain.Middlewares, func(name string) bool {
+				return reg.MatchString(name)
+			})
+			if !found {
+				result = append(result, fmt.Sprintf(localize("%s misses %s in [%s]"), chain.Router, patterns[i], strings.Join(chain.Middlewares, " ")))
+				return
+			}
+			if anchored && i == 0 && at > 0 {
+				result = append(result, fmt.Sprintf(localize("%s does not start with %s in [%s]"), chain.Router, patterns[i], strings.Join(chain.Middlewares, " ")))
+				return
+			}
+			if at < last {
+				result = append(result, fmt.Sprintf(localize("%s registers %s out of order in [%s]"), chain.Router, patterns[i], strings.Join(chain.Middlewares, " ")))
+				return
+			}
+			last = at
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("middlewares are not in order %v: %w"), patterns, Violations(result))).Else(nil)
+}
@@ -0,0 +1,37 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMiddlewareChains(t *testing.T) {
+	arch, err := Load("testdata/middleware")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("middleware")
+	assert.NoError(t, err)
+	chains := pkgs.MiddlewareChains()
+	assert.Equal(t, MiddlewareChains{
+		{Router: "example.com/middleware.Ordered r", Middlewares: []string{"example.com/middleware.Recovery", "example.com/middleware.Logging", "example.com/middleware.Auth"}},
+		{Router: "example.com/middleware.Reversed r", Middlewares: []string{"example.com/middleware.Auth", "example.com/middleware.Recovery"}},
+		{Router: "example.com/middleware.Missing r", Middlewares: []string{"example.com/middleware.Recovery"}},
+		{Router: "example.com/middleware.Chained e", Middlewares: []string{"example.com/middleware.Recovery", "example.com/middleware.Logging", "example.com/middleware.Auth"}},
+		{Router: "example.com/middleware.Late r", Middlewares: []string{"example.com/middleware.Logging", "example.com/middleware.Recovery", "example.com/middleware.Auth"}},
+	}, chains)
+	assert.NoError(t, chains.ShouldBeInOrder("Recovery$"))
+	err = chains.ShouldBeInOrder("Recovery$", "Auth$")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"example.com/middleware.Reversed r registers Auth$ out of order in [example.com/middleware.Auth example.com/middleware.Recovery]",
+		"example.com/middleware.Missing r misses Auth$ in [example.com/middleware.Recovery]",
+	}, violations)
+	err = chains.ShouldStartWith("Recovery$", "Auth$")
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"example.com/middleware.Reversed r does not start with Recovery$ in [example.com/middleware.Auth example.com/middleware.Recovery]",
+		"example.com/middleware.Missing r misses Auth$ in [example.com/middleware.Recovery]",
+		"example.com/middleware.Late r does not start with Recovery$ in [example.com/middleware.Logging example.com/middleware.Recovery example.com/middleware.Auth]",
+	}, violations)
+	assert.Error(t, chains.ShouldBeInOrder("[a"))
+}
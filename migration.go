@@ -0,0 +1,93 @@
+package archunit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MigrationsShouldBeSequential checks the migration files in the folder, relative to the project root, are numbered
+// sequentially without gaps or duplicates. the pattern is the regular expression of the migration file names with the
+// number as the first group, eg: ^(\d+)_.*\.up\.sql$. files not matching the pattern are ignored
+func MigrationsShouldBeSequential(folder, pattern string) error {
+	return Project().MigrationsShouldBeSequential(folder, pattern)
+}
+
+// MigrationsShouldBeSequential checks the migration files in the folder relative to the root of the architecture
+func (arch Architecture) MigrationsShouldBeSequential(folder, pattern string) error {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(filepath.Join(arch.RootDir(), folder))
+	if err != nil {
+		return err
+	}
+	numbers := map[int][]string{}
+	for _, entry := range entries {
+		if match := reg.FindStringSubmatch(entry.Name()); !entry.IsDir() && len(match) > 1 {
+			if n, err := strconv.Atoi(match[1]); err == nil {
+				numbers[n] = append(numbers[n], entry.Name())
+			}
+		}
+	}
+	keys := lo.Keys(numbers)
+	sort.Ints(keys)
+	var result []string
+	for i, n := range keys {
+		if len(numbers[n]) > 1 {
+			result = append(result, fmt.Sprintf(localize("%v share number %d"), numbers[n], n))
+		}
+		if i > 0 && n != keys[i-1]+1 {
+			result = append(result, fmt.Sprintf(localize("%s does not follow %s"), numbers[n][0], numbers[keys[i-1]][0]))
+		}
+	}
+	return lo.If(len(result) > 0, fmt.Errorf(localize("migrations in %s are not sequential: %w"), folder, Violations(result))).Else(nil)
+}
+
+// MigrationsShouldNotBeModified checks the migration files recorded in the baseline store are neither modified nor
+// removed. the store is in the format of sha256sum, one "checksum  path" per line with the paths relative to the
+// project root, eg: sha256sum migrations/*.sql > migrations.sum
+func MigrationsShouldNotBeModified(baselineStore string) error {
+	return Project().MigrationsShouldNotBeModified(baselineStore)
+}
+
+// MigrationsShouldNotBeModified checks the migration files of the baseline store relative to the root of the
+// architecture
+func (arch Architecture) MigrationsShouldNotBeModified(baselineStore string) error {
+	root := arch.RootDir()
+	file, err := os.Open(filepath.Join(root, baselineStore))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var result []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		checksum, path, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok {
+			continue
+		}
+		path = strings.TrimPrefix(strings.TrimSpace(path), "*")
+		data, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			result = append(result, fmt.Sprintf(localize("%s is removed"), path))
+			continue
+		}
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != checksum {
+			result = append(result, fmt.Sprintf(localize("%s is modified"), path))
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	return lo.If(len(result) > 0, fmt.Errorf(localize("migrations of %s are changed: %w"), baselineStore, Violations(result))).Else(nil)
+}
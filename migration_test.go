@@ -0,0 +1,30 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMigrationsShouldBeSequential(t *testing.T) {
+	assert.NoError(t, MigrationsShouldBeSequential("testdata/migrations", `^(\d+)_.*\.down\.sql$`))
+	err := MigrationsShouldBeSequential("testdata/migrations", `^(\d+)_.*\.up\.sql$`)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"004_orders.up.sql does not follow 002_users.up.sql"}, violations)
+	err = MigrationsShouldBeSequential("testdata/migrations", `^(\d)\d+_.*\.sql$`)
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"[001_init.down.sql 001_init.up.sql 002_users.up.sql 004_orders.up.sql] share number 0"}, violations)
+	assert.Error(t, MigrationsShouldBeSequential("testdata/migrations", "[a"))
+	assert.Error(t, MigrationsShouldBeSequential("testdata/absent", `^(\d+)`))
+}
+
+func TestMigrationsShouldNotBeModified(t *testing.T) {
+	err := MigrationsShouldNotBeModified("testdata/migrations.sum")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"testdata/migrations/004_orders.up.sql is modified",
+		"testdata/migrations/003_absent.up.sql is removed",
+	}, violations)
+	assert.Error(t, MigrationsShouldNotBeModified("testdata/absent.sum"))
+}
@@ -0,0 +1,113 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// ShouldNotRangeOverMapWhenBuildingOrderedOutput checks the exported functions of the packages do not build the
+// returned slices by appending in a range loop over a map, as the iteration order of maps is random and so is the
+// result. it is a heuristic rule: the slice is considered ordered once it is passed to the sort or slices packages
+func (archPkg ArchPackage) ShouldNotRangeOverMapWhenBuildingOrderedOutput() error {
+	var result Findings
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		arch := Architecture{artifact: pkg.Artifact()}
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Body == nil || !fd.Name.IsExported() ||
+					arch.ignored("ArchPackage.ShouldNotRangeOverMapWhenBuildingOrderedOutput", info.Defs[fd.Name]) {
+					return
+				}
+				returned, sorted := returnedAndSorted(fd, info)
+				ast.Inspect(fd.Body, func(node ast.Node) bool {
+					loop, ok := node.(*ast.RangeStmt)
+					if !ok {
+						return true
+					}
+					if _, ok = info.TypeOf(loop.X).Underlying().(*types.Map); !ok {
+						return true
+					}
+					ast.Inspect(loop.Body, func(node ast.Node) bool {
+						if assign, ok := node.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+							for i, lhs := range assign.Lhs {
+								obj := objectOf(lhs, info)
+								if obj != nil && returned[obj] && !sorted[obj] && appends(assign.Rhs[i], info) {
+									position := pkg.Raw().Fset.Position(assign.Pos())
+									result = append(result, violationAt(position, fmt.Sprintf(localize("%s builds %s at %s"), fd.Name.Name, obj.Name(), position)))
+								}
+							}
+						}
+						return true
+					})
+					return true
+				})
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("ordered outputs are built by ranging over maps: %w"), result.sorted())).Else(nil)
+}
+
+// returnedAndSorted returns the variables returned by the function and the ones sorted by the sort or slices packages
+func returnedAndSorted(fd *ast.FuncDecl, info *types.Info) (map[types.Object]bool, map[types.Object]bool) {
+	returned := map[types.Object]bool{}
+	sorted := map[types.Object]bool{}
+	if fd.Type.Results != nil {
+		lo.ForEach(fd.Type.Results.List, func(field *ast.Field, _ int) {
+			lo.ForEach(field.Names, func(name *ast.Ident, _ int) {
+				returned[info.Defs[name]] = true
+			})
+		})
+	}
+	ast.Inspect(fd.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			lo.ForEach(n.Results, func(expr ast.Expr, _ int) {
+				if obj := objectOf(expr, info); obj != nil {
+					returned[obj] = true
+				}
+			})
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				if f, ok := info.Uses[sel.Sel].(*types.Func); ok && f.Pkg() != nil &&
+					(f.Pkg().Path() == "sort" || f.Pkg().Path() == "slices" && strings.HasPrefix(f.Name(), "Sort")) {
+					lo.ForEach(n.Args, func(arg ast.Expr, _ int) {
+						if obj := objectOf(arg, info); obj != nil {
+							sorted[obj] = true
+						}
+					})
+				}
+			}
+		}
+		return true
+	})
+	return returned, sorted
+}
+
+// objectOf returns the variable referred by the identifier expression, or nil for other expressions
+func objectOf(expr ast.Expr, info *types.Info) types.Object {
+	if id, ok := ast.Unparen(expr).(*ast.Ident); ok {
+		if v, ok := info.ObjectOf(id).(*types.Var); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// appends reports whether the expression is a call of builtin append
+func appends(expr ast.Expr, info *types.Info) bool {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if id, ok := call.Fun.(*ast.Ident); ok {
+			_, builtin := info.Uses[id].(*types.Builtin)
+			return builtin && id.Name == "append"
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArchPackage_ShouldNotRangeOverMapWhenBuildingOrderedOutput(t *testing.T) {
+	flags, _ := Packages("sample/flags")
+	err := flags.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Names builds names at")
+	assert.Contains(t, err.Error(), "sample/flags/flags.go:27:4")
+	assert.NotContains(t, err.Error(), "SortedNames")
+	service, _ := Packages("sample/service/...")
+	assert.NoError(t, service.ShouldNotRangeOverMapWhenBuildingOrderedOutput())
+}
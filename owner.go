@@ -0,0 +1,164 @@
+package archunit
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/samber/lo"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Unowned is the team of the violations without any owner in the reports of TeamReporter
+var Unowned = "unowned"
+
+// teamFileChars matches the characters of the team names not allowed in the file names of TeamReporter.PerTeam
+var teamFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Ownership is the owners of the paths of the project, loaded from a CODEOWNERS file by LoadOwnership
+type Ownership []ownerEntry
+
+type ownerEntry struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// LoadOwnership loads the ownership from the CODEOWNERS file, eg: .github/CODEOWNERS. the patterns are resolved
+// against the root directory of the architecture and the last matching pattern wins, the same as GitHub
+func LoadOwnership(file string) (Ownership, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf(localize("can not load ownership %s: %w"), file, err)
+	}
+	defer f.Close()
+	var ownership Ownership
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := ownerPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf(localize("invalid pattern at %s:%d: %w"), file, line, err)
+		}
+		ownership = append(ownership, ownerEntry{pattern: pattern, owners: fields[1:]})
+	}
+	return ownership, scanner.Err()
+}
+
+// ownerPattern converts the CODEOWNERS pattern to the regular expression of the slash separated relative paths. the
+// patterns without slashes match at any depth, and the patterns of directories match all the paths under them
+func ownerPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dir := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+	var expr strings.Builder
+	expr.WriteString(lo.If(anchored, "^").Else("^(?:.*/)?"))
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString(lo.If(dir, "/.*$").Else("(?:/.*)?$"))
+	return regexp.Compile(expr.String())
+}
+
+// Owners returns the owners of the slash separated path relative to the root directory, eg: internal/service/user.go
+func (ownership Ownership) Owners(path string) []string {
+	for i := len(ownership) - 1; i >= 0; i-- {
+		if ownership[i].pattern.MatchString(path) {
+			return ownership[i].owners
+		}
+	}
+	return nil
+}
+
+// AttributeOwners attributes the violations reported by Architecture.ValidateWithReport to their owners, by the file
+// of the violation or the directory of the package the violation mentions
+func AttributeOwners(ownership Ownership) ReportOption {
+	return func(report *reportOptions) {
+		report.ownership = ownership
+	}
+}
+
+// owners returns the owners of the violation by the ownership of the report options
+func (arch Architecture) owners(violation Violation) []string {
+	if len(arch.report.ownership) == 0 {
+		return nil
+	}
+	file := violation.File
+	if pkg := arch.mentioned(violation.Message); file == "" && pkg != nil && len(pkg.GoFiles()) > 0 {
+		file = pkg.GoFiles()[0]
+	}
+	rel, err := filepath.Rel(arch.RootDir(), file)
+	if file == "" || err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	return arch.report.ownership.Owners(filepath.ToSlash(rel))
+}
+
+// TeamReporter writes the violations grouped by their owners, the violations owned by several teams are reported to
+// all of them. use it along with AttributeOwners
+type TeamReporter struct {
+	w   io.Writer
+	dir string
+}
+
+// NewTeamReporter creates the reporter writing the violations of all the teams to w, eg:
+// arch.With(AttributeOwners(ownership)).ValidateWithReport(NewTeamReporter(os.Stdout), rules...)
+func NewTeamReporter(w io.Writer) *TeamReporter {
+	return &TeamReporter{w: w}
+}
+
+// PerTeam returns the reporter also writing the violations of each team to a file of the directory, the file is
+// named after the team, eg: org-payments.txt for @org/payments
+func (reporter *TeamReporter) PerTeam(dir string) *TeamReporter {
+	reporter.dir = dir
+	return reporter
+}
+
+func (reporter *TeamReporter) Report(_ Architecture, results []RuleResult) error {
+	teams := map[string][]string{}
+	lo.ForEach(results, func(result RuleResult, _ int) {
+		lo.ForEach(result.Violations, func(violation Violation, _ int) {
+			line := fmt.Sprintf("%s: %s", result.Rule, violation.Message)
+			lo.ForEach(lo.If(len(violation.Owners) > 0, violation.Owners).Else([]string{Unowned}), func(team string, _ int) {
+				teams[team] = append(teams[team], line)
+			})
+		})
+	})
+	names := lo.Keys(teams)
+	sort.Slice(names, func(i, j int) bool {
+		return names[j] == Unowned || names[i] != Unowned && names[i] < names[j]
+	})
+	for _, team := range names {
+		report := fmt.Sprintf("%s (%d violations)\n  %s\n", team, len(teams[team]), strings.Join(teams[team], "\n  "))
+		if _, err := io.WriteString(reporter.w, report); err != nil {
+			return err
+		}
+		if reporter.dir == "" {
+			continue
+		}
+		file := filepath.Join(reporter.dir, strings.Trim(teamFileChars.ReplaceAllString(team, "-"), "-")+".txt")
+		if err := os.WriteFile(file, []byte(report), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package archunit
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadOwnership(t *testing.T) {
+	ownership, err := LoadOwnership("testdata/owners/CODEOWNERS")
+	assert.NoError(t, err)
+	assert.Len(t, ownership, 5)
+	assert.Equal(t, []string{"@org/platform"}, ownership.Owners("go.mod"))
+	assert.Equal(t, []string{"@org/platform"}, ownership.Owners("cmd/internal/sample/main.go"))
+	assert.Equal(t, []string{"@org/sample"}, ownership.Owners("internal/sample/service/user_service.go"))
+	assert.Equal(t, []string{"@org/frontend", "@org/design"}, ownership.Owners("internal/sample/views/UserView.go"))
+	assert.Equal(t, []string{"@org/flags"}, ownership.Owners("internal/sample/flags/flags.go"))
+	assert.Empty(t, ownership.Owners("README.md"))
+	_, err = LoadOwnership("testdata/owners/MISSING")
+	assert.Error(t, err)
+}
+
+func TestTeamReporter_Report(t *testing.T) {
+	ownership, err := LoadOwnership("testdata/owners/CODEOWNERS")
+	assert.NoError(t, err)
+	ordering := NewRule("ordering", func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+	}, "sample/...")
+	naming := NewRule("naming", func(pkgs ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views")
+	recorder := &recordReporter{}
+	arch := Project().With(AttributeOwners(ownership))
+	assert.Error(t, arch.ValidateWithReport(recorder, ordering, naming))
+	assert.Equal(t, []string{"@org/flags"}, recorder.results[0].Violations[0].Owners)
+	assert.Equal(t, []string{"@org/frontend", "@org/design"}, recorder.results[1].Violations[0].Owners)
+	assert.NoError(t, Project().ValidateWithReport(recorder, NewRule("none", func(_ ArchPackage) error {
+		return nil
+	})))
+	assert.Error(t, Project().ValidateWithReport(recorder, ordering))
+	assert.Empty(t, recorder.results[0].Violations[0].Owners)
+	unowned := NewRule("unowned", func(_ ArchPackage) error {
+		return fmt.Errorf("found %w", Violations{"a"})
+	})
+	var buf bytes.Buffer
+	dir := t.TempDir()
+	assert.Error(t, arch.ValidateWithReport(NewTeamReporter(&buf).PerTeam(dir), ordering, naming, unowned))
+	assert.Equal(t, `@org/design (1 violations)
+  naming: package name and folder not the same: [github.com/kcmvp/archunit/internal/sample/views]
+@org/flags (1 violations)
+  ordering: Names builds names at `+filepath.Join(Project().RootDir(), "internal/sample/flags/flags.go")+`:27:4
+@org/frontend (1 violations)
+  naming: package name and folder not the same: [github.com/kcmvp/archunit/internal/sample/views]
+unowned (1 violations)
+  unowned: a
+`, buf.String())
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"org-design.txt", "org-flags.txt", "org-frontend.txt", "unowned.txt"}, lo.Map(entries, func(entry os.DirEntry, _ int) string {
+		return entry.Name()
+	}))
+	content, _ := os.ReadFile(filepath.Join(dir, "unowned.txt"))
+	assert.Equal(t, "unowned (1 violations)\n  unowned: a\n", string(content))
+}
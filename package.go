@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"github.com/kcmvp/archunit/internal"
 	"github.com/samber/lo"
-	"regexp"
+	"go/types"
+	"sort"
 	"strings"
 )
 
@@ -16,12 +17,16 @@ func AllPackages() ArchPackage {
 }
 
 func Packages(paths ...string) (ArchPackage, error) {
-	patterns, err := ScopePattern(paths...)
-	return lo.Filter(AllPackages(), func(pkg *internal.Package, _ int) bool {
-		return lo.ContainsBy(patterns, func(pattern *regexp.Regexp) bool {
-			return pattern.MatchString(pkg.ID())
-		})
-	}), err
+	return Project().Packages(paths...)
+}
+
+// architecture returns the architecture the packages belong to, an empty selection belongs to an empty architecture
+// so it checks nothing, the rules get the architecture they are validated on from Architecture.Validate
+func (archPkg ArchPackage) architecture() Architecture {
+	if len(archPkg) > 0 {
+		return Architecture{artifact: archPkg[0].Artifact()}
+	}
+	return Architecture{artifact: &internal.Artifact{}}
 }
 
 func (archPkg ArchPackage) ID() []string {
@@ -62,6 +67,26 @@ func (archPkg ArchPackage) Functions() Functions {
 	return functions
 }
 
+// Closures returns the function literals assigned to the package level variables or returned by the exported functions
+// of the packages, they are not selected by Functions, append them to apply the function rules on the closures too
+func (archPkg ArchPackage) Closures() Functions {
+	var functions Functions
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		functions = append(functions, pkg.Closures()...)
+	})
+	return functions
+}
+
+// TestFunctions returns the functions declared in the _test.go files of the packages,
+// such as tests, benchmarks, fuzz tests and examples
+func (archPkg ArchPackage) TestFunctions() Functions {
+	var functions Functions
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		functions = append(functions, pkg.TestFunctions()...)
+	})
+	return functions
+}
+
 func (archPkg ArchPackage) Files() FileSet {
 	var files []PackageFile
 	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
@@ -74,7 +99,7 @@ func (archPkg ArchPackage) NameShouldBeSameAsFolder() error {
 	result := lo.FilterMap(archPkg, func(pkg *internal.Package, _ int) (string, bool) {
 		return pkg.ID(), !strings.HasSuffix(pkg.ID(), pkg.Name())
 	})
-	return lo.If(len(result) > 0, fmt.Errorf("package name and folder not the same: %v", archPkg.ID())).Else(nil)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("package name and folder not the same: %v"), archPkg.ID())).Else(nil)
 }
 
 func (archPkg ArchPackage) NameShould(pattern NamePattern, args ...string) error {
@@ -83,7 +108,7 @@ func (archPkg ArchPackage) NameShould(pattern NamePattern, args ...string) error
 			return args[0]
 		}))
 	}); ok {
-		return fmt.Errorf("package %s's name is %s", pkg.ID(), pkg.Name())
+		return fmt.Errorf(localize("package %s's name is %s"), pkg.ID(), pkg.Name())
 	}
 	return nil
 }
@@ -98,13 +123,13 @@ func (archPkg ArchPackage) ShouldNotRefer(referred ...ArchPackage) error {
 	if pkg, ok := lo.Find(archPkg, func(pkg *internal.Package) bool {
 		return lo.Some(pkg.Imports(), ids)
 	}); ok {
-		return fmt.Errorf("%s referrs %v", pkg.ID(), ids)
+		return fmt.Errorf(localize("%s referrs %v"), pkg.ID(), ids)
 	}
 	return nil
 }
 
 func (archPkg ArchPackage) ShouldNotReferPkgPaths(paths ...string) error {
-	pkgs, err := Packages(paths...)
+	pkgs, err := archPkg.architecture().Packages(paths...)
 	if err != nil {
 		return err
 	}
@@ -116,12 +141,12 @@ func (archPkg ArchPackage) ShouldBeOnlyReferredByPackages(referrings ...ArchPack
 	lo.ForEach(referrings, func(ref ArchPackage, _ int) {
 		refIDs = append(refIDs, ref.Imports()...)
 	})
-	if pkg, ok := lo.Find(internal.Arch().Packages(), func(pkg *internal.Package) bool {
+	if pkg, ok := lo.Find(archPkg.architecture().artifact.Packages(), func(pkg *internal.Package) bool {
 		return lo.If(lo.Contains(archPkg, pkg), false).ElseF(func() bool {
 			return lo.Some(pkg.Imports(), archPkg.ID()) && !lo.Contains(refIDs, pkg.ID())
 		})
 	}); ok {
-		return fmt.Errorf("%s referrs %v", pkg.ID(), refIDs)
+		return fmt.Errorf(localize("%s referrs %v"), pkg.ID(), refIDs)
 	}
 	return nil
 }
@@ -132,13 +157,13 @@ func (archPkg ArchPackage) ShouldOnlyReferPackages(referred ...ArchPackage) erro
 		ids = append(ids, pkg.ID()...)
 	})
 	if d1, _ := lo.Difference(archPkg.Imports(), ids); len(d1) > 0 {
-		return fmt.Errorf("reference %v are out of scope %v", d1, ids)
+		return fmt.Errorf(localize("reference %v are out of scope %v"), d1, ids)
 	}
 	return nil
 }
 
 func (archPkg ArchPackage) ShouldOnlyReferPkgPaths(paths ...string) error {
-	pkg, err := Packages(paths...)
+	pkg, err := archPkg.architecture().Packages(paths...)
 	if err != nil {
 		return err
 	}
@@ -146,9 +171,55 @@ func (archPkg ArchPackage) ShouldOnlyReferPkgPaths(paths ...string) error {
 }
 
 func (archPkg ArchPackage) ShouldBeOnlyReferredByPkgPaths(paths ...string) error {
-	pkg, err := Packages(paths...)
+	pkg, err := archPkg.architecture().Packages(paths...)
 	if err != nil {
 		return err
 	}
 	return archPkg.ShouldBeOnlyReferredByPackages(pkg)
 }
+
+// SymbolsOf returns the distinct symbols of the packages of the paths used by each package of the selection,
+// keyed by the package id. methods are counted as symbols as well, so the result measures how heavy the coupling is
+func (archPkg ArchPackage) SymbolsOf(paths ...string) (map[string][]string, error) {
+	targets, err := archPkg.architecture().Packages(paths...)
+	if err != nil {
+		return nil, err
+	}
+	ids := targets.ID()
+	symbols := map[string][]string{}
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		var used []string
+		for _, obj := range pkg.Raw().TypesInfo.Uses {
+			if obj.Pkg() == nil || obj.Pkg().Path() == pkg.ID() || !lo.Contains(ids, obj.Pkg().Path()) {
+				continue
+			}
+			if f, ok := obj.(*types.Func); ok || obj.Parent() == obj.Pkg().Scope() {
+				if ok {
+					obj = f.Origin()
+				}
+				used = append(used, qualifiedName(obj))
+			}
+		}
+		if used = lo.Uniq(used); len(used) > 0 {
+			sort.Strings(used)
+			symbols[pkg.ID()] = used
+		}
+	})
+	return symbols, nil
+}
+
+// ShouldUseAtMostSymbolsOf checks each package of the selection uses at most n distinct symbols of the packages of the target,
+// which bounds the coupling rather than only forbidding it. the used symbols are listed in the report
+func (archPkg ArchPackage) ShouldUseAtMostSymbolsOf(target string, n int) error {
+	symbols, err := archPkg.SymbolsOf(target)
+	if err != nil {
+		return err
+	}
+	var result []string
+	lo.ForEach(archPkg, func(pkg *internal.Package, _ int) {
+		if used := symbols[pkg.ID()]; len(used) > n {
+			result = append(result, fmt.Sprintf(localize("%s uses %d symbols of %s %v"), pkg.ID(), len(used), target, used))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("packages use more than %d symbols: %w"), n, Violations(result))).Else(nil)
+}
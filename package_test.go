@@ -10,14 +10,14 @@ import (
 
 func TestPackages_NameShouldBeSameAsFolder(t *testing.T) {
 	pkgs := AllPackages()
-	assert.Equal(t, 14, len(pkgs))
+	assert.Equal(t, 23, len(pkgs))
 	err := pkgs.NameShouldBeSameAsFolder()
 	assert.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "archunit/internal/sample/views"))
 	assert.True(t, strings.Contains(err.Error(), "archunit/internal/sample/service/thirdparty"))
 	assert.True(t, strings.Contains(err.Error(), "archunit/internal/sample"))
 	pkgs = pkgs.Skip("internal/sample/views", "sample/service/thirdparty", "archunit/internal/sample")
-	assert.Equal(t, 12, len(pkgs))
+	assert.Equal(t, 21, len(pkgs))
 	err = pkgs.NameShouldBeSameAsFolder()
 	assert.NoError(t, err)
 }
@@ -32,19 +32,19 @@ func TestPackageNameShould(t *testing.T) {
 
 func TestPackage(t *testing.T) {
 	pkgs, _ := Packages("internal/sample/...")
-	assert.Equal(t, 12, len(pkgs))
-	assert.Equal(t, 12, len(pkgs.ID()))
-	assert.Equal(t, 12, len(pkgs.Files()))
+	assert.Equal(t, 13, len(pkgs))
+	assert.Equal(t, 13, len(pkgs.ID()))
+	assert.Equal(t, 13, len(pkgs.Files()))
 	var files []string
 	lo.ForEach(pkgs.Files(), func(f PackageFile, _ int) {
 		files = append(files, f.B...)
 	})
-	assert.Equal(t, 14, len(files))
+	assert.Equal(t, 17, len(files))
 	assert.True(t, lo.NoneBy(files, func(f string) bool {
 		return strings.HasSuffix(f, "main.go")
 	}))
-	assert.Equal(t, 19, len(pkgs.Types()))
-	assert.Equal(t, 2, len(pkgs.Functions()))
+	assert.Equal(t, 20, len(pkgs.Types()))
+	assert.Equal(t, 11, len(pkgs.Functions()))
 }
 
 func TestPackage_Ref(t *testing.T) {
@@ -74,6 +74,32 @@ func TestPackage_Ref(t *testing.T) {
 	})
 	assert.ElementsMatch(t, lo.Map(controller.Functions(), func(item internal.Function, index int) string {
 		return item.Name()
-	}), []string{"LoginHandler"})
+	}), []string{"LoginHandler", "Routes"})
 
 }
+
+func TestArchPackage_ShouldUseAtMostSymbolsOf(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	symbols, err := pkgs.SymbolsOf("sample/service")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"github.com/kcmvp/archunit/internal/sample/controller": {
+			"(github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl).FirstNameI",
+			"github.com/kcmvp/archunit/internal/sample/service.NameService",
+			"github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl",
+			"github.com/kcmvp/archunit/internal/sample/service.UserService",
+		},
+		"github.com/kcmvp/archunit/internal/sample/service/ext/v2": {
+			"github.com/kcmvp/archunit/internal/sample/service.UserService",
+		},
+	}, symbols)
+	assert.NoError(t, pkgs.ShouldUseAtMostSymbolsOf("sample/service", 4))
+	err = pkgs.ShouldUseAtMostSymbolsOf("sample/service", 3)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasPrefix(violations[0], "github.com/kcmvp/archunit/internal/sample/controller uses 4 symbols of sample/service "))
+	_, err = pkgs.SymbolsOf("sample/[a")
+	assert.Error(t, err)
+}
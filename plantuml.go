@@ -0,0 +1,108 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	umlComponent  = regexp.MustCompile(`^(?:component\s+)?\[([^\]]+)\](?:\s+as\s+(\w+))?((?:\s*<<[^>]+>>)+)\s*$`)
+	umlStereotype = regexp.MustCompile(`<<\s*([^>]+?)\s*>>`)
+	umlArrow      = regexp.MustCompile(`^(\[[^\]]+\]|\w+)\s*(<?)[-.]+(?:(?:up|down|left|right)[-.]+)?(>?)\s*(\[[^\]]+\]|\w+)\s*(?::.*)?$`)
+)
+
+// umlDiagram is the components of a PlantUML component diagram with the package paths of their stereotypes and the
+// components each component may depend on
+type umlDiagram struct {
+	components map[string][]string
+	arrows     map[string][]string
+}
+
+// AdhereToPlantUML checks the imports between the packages of the module match the arrows of the PlantUML component
+// diagram. the packages of a component are the paths of its stereotypes, the packages out of the components are not
+// checked, eg:
+//
+//	[Controller] <<sample/controller/...>>
+//	[Service] as service <<sample/service/...>>
+//	[Controller] --> service
+func (arch Architecture) AdhereToPlantUML(file string) error {
+	diagram, err := parsePlantUML(file)
+	if err != nil {
+		return err
+	}
+	members := map[string][]string{}
+	for name, paths := range diagram.components {
+		pkgs, err := arch.Packages(paths...)
+		if err != nil {
+			return fmt.Errorf(localize("component %s: %w"), name, err)
+		}
+		lo.ForEach(pkgs.ID(), func(pkg string, _ int) {
+			members[pkg] = append(members[pkg], name)
+		})
+	}
+	graph := arch.DependencyGraph()
+	var result []string
+	lo.ForEach(graph.Packages, func(pkg string, _ int) {
+		lo.ForEach(graph.Imports[pkg], func(imported string, _ int) {
+			for _, from := range members[pkg] {
+				for _, to := range members[imported] {
+					if from != to && !lo.Contains(diagram.arrows[from], to) {
+						result = append(result, fmt.Sprintf(localize("%s -> %s: %s imports %s"), from, to, pkg, imported))
+					}
+				}
+			}
+		})
+	})
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("dependencies are not in %s: %w"), file, Violations(result))).Else(nil)
+}
+
+// parsePlantUML reads the components and the arrows of the diagram, the components are referred by their names in
+// brackets or their aliases, the other elements of the diagram are ignored
+func parsePlantUML(file string) (umlDiagram, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return umlDiagram{}, err
+	}
+	diagram := umlDiagram{components: map[string][]string{}, arrows: map[string][]string{}}
+	aliases := map[string]string{}
+	var arrows [][2]string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if match := umlComponent.FindStringSubmatch(line); match != nil {
+			name := strings.TrimSpace(match[1])
+			for _, stereotype := range umlStereotype.FindAllStringSubmatch(match[3], -1) {
+				diagram.components[name] = append(diagram.components[name], stereotype[1])
+			}
+			aliases["["+name+"]"] = name
+			if match[2] != "" {
+				aliases[match[2]] = name
+			}
+		} else if match = umlArrow.FindStringSubmatch(line); match != nil && (match[2] == "") != (match[3] == "") {
+			from, to := match[1], match[4]
+			if match[2] != "" {
+				from, to = to, from
+			}
+			arrows = append(arrows, [2]string{from, to})
+		} else if line != "" && !strings.HasPrefix(line, "'") && !strings.HasPrefix(line, "@") &&
+			strings.ContainsAny(line, "[>") {
+			return umlDiagram{}, fmt.Errorf(localize("%s:%d: unsupported element %s"), file, i+1, line)
+		}
+	}
+	for _, arrow := range arrows {
+		from, ok := aliases[arrow[0]]
+		if !ok {
+			return umlDiagram{}, fmt.Errorf(localize("%s: undefined component %s"), file, arrow[0])
+		}
+		to, ok := aliases[arrow[1]]
+		if !ok {
+			return umlDiagram{}, fmt.Errorf(localize("%s: undefined component %s"), file, arrow[1])
+		}
+		diagram.arrows[from] = append(diagram.arrows[from], to)
+	}
+	return diagram, nil
+}
@@ -0,0 +1,46 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArchitecture_AdhereToPlantUML(t *testing.T) {
+	arch, err := Load("testdata/cycle")
+	assert.NoError(t, err)
+	tests := []struct {
+		name string
+		file string
+		err  string
+	}{
+		{
+			name: "missing arrow",
+			file: "testdata/plantuml/cycle.puml",
+			err:  "dependencies are not in testdata/plantuml/cycle.puml: [Service -> Model: example.com/cycle/service imports example.com/cycle/model]",
+		},
+		{
+			name: "conform",
+			file: "testdata/plantuml/cycle-conform.puml",
+		},
+		{
+			name: "undefined component",
+			file: "testdata/plantuml/undefined.puml",
+			err:  "testdata/plantuml/undefined.puml: undefined component [Service]",
+		},
+		{
+			name: "absent",
+			file: "testdata/plantuml/absent.puml",
+			err:  "open testdata/plantuml/absent.puml: no such file or directory",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := arch.AdhereToPlantUML(test.file)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// OSSpecificAPIs is the platform specific packages and identifiers, eg: syscall or os.Getuid, append the APIs of
+// other platform specific libraries to it
+var OSSpecificAPIs = []string{"syscall", "golang.org/x/sys", "os.Getuid", "os.Geteuid", "os.Getgid", "os.Getegid",
+	"os.Getgroups", "os.Chown", "os.Lchown", "os.Getpagesize", "runtime.GOOS"}
+
+// OSSpecificCallsShouldBeLimitedTo checks the OSSpecificAPIs are only used in the platform adapter packages of the paths,
+// so the portability concerns are isolated
+func OSSpecificCallsShouldBeLimitedTo(paths ...string) error {
+	return Project().OSSpecificCallsShouldBeLimitedTo(paths...)
+}
+
+// OSSpecificCallsShouldBeLimitedTo checks the OSSpecificAPIs are only used in the packages of the paths of the architecture
+func (arch Architecture) OSSpecificCallsShouldBeLimitedTo(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	var result Findings
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		if lo.SomeBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(pkg.ID())
+		}) {
+			return
+		}
+		for id, obj := range pkg.Raw().TypesInfo.Uses {
+			if osSpecific(obj) {
+				position := pkg.Raw().Fset.Position(id.Pos())
+				result = append(result, violationAt(position, fmt.Sprintf("%s %s", position, qualifiedName(obj))))
+			}
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("os specific apis are used out of %v: %w"), paths, result.sorted())).Else(nil)
+}
+
+func osSpecific(obj types.Object) bool {
+	if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return false
+	}
+	name := fmt.Sprintf("%s.%s", obj.Pkg().Path(), obj.Name())
+	return lo.SomeBy(OSSpecificAPIs, func(api string) bool {
+		return obj.Pkg().Path() == api || strings.HasPrefix(obj.Pkg().Path(), api+"/") || name == api
+	})
+}
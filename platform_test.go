@@ -0,0 +1,17 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestOSSpecificCallsShouldBeLimitedTo(t *testing.T) {
+	assert.NoError(t, OSSpecificCallsShouldBeLimitedTo("sample/service/thirdparty"))
+	err := OSSpecificCallsShouldBeLimitedTo("sample/flags")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "thirdparty/local.go:37:17 syscall.Getuid"))
+	assert.Error(t, OSSpecificCallsShouldBeLimitedTo("sample/[a"))
+}
@@ -0,0 +1,54 @@
+package archunit
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RuleProvider provides the rules of an organization, eg: proprietary rules shipped as a go plugin loaded by package
+// plugin. the rules are selected by the name of the provider, so the architecture tests or tools run them without
+// depending on their code
+type RuleProvider interface {
+	Name() string
+	Rules() []Rule
+}
+
+var (
+	providerMu sync.RWMutex
+	providers  = map[string]RuleProvider{}
+)
+
+// RegisterProvider registers the provider by its name, registering the same name again replaces the provider
+func RegisterProvider(provider RuleProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	providers[provider.Name()] = provider
+}
+
+// Providers returns the names of the registered providers in alphabetical order
+func Providers() []string {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProvidedRules returns the rules of the registered providers of the names
+func ProvidedRules(names ...string) ([]Rule, error) {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	var rules []Rule
+	for _, name := range names {
+		provider, ok := providers[name]
+		if !ok {
+			return nil, fmt.Errorf(localize("can not find rule provider %s"), name)
+		}
+		rules = append(rules, provider.Rules()...)
+	}
+	return rules, nil
+}
@@ -0,0 +1,38 @@
+// Package plugin loads the rule providers shipped as go plugins, it is separated from the core so the programs
+// which do not load plugins do not link the plugin package of the standard library
+package plugin
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"plugin"
+)
+
+// ProviderSymbol is the symbol of the RuleProvider a go plugin exports, eg: var Provider archunit.RuleProvider = orgRules{}
+var ProviderSymbol = "Provider"
+
+// Load opens the go plugin built by `go build -buildmode=plugin` and registers the provider it exports as
+// ProviderSymbol, see archunit.RegisterProvider. the plugin must be built by the same go toolchain with the same
+// version of archunit
+func Load(path string) (archunit.RuleProvider, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not open plugin %s: %w", path, err)
+	}
+	symbol, err := p.Lookup(ProviderSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("can not find %s in plugin %s: %w", ProviderSymbol, path, err)
+	}
+	var provider archunit.RuleProvider
+	switch v := symbol.(type) {
+	case *archunit.RuleProvider:
+		provider = *v
+	case archunit.RuleProvider:
+		provider = v
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("%s of plugin %s is %T rather than archunit.RuleProvider", ProviderSymbol, path, symbol)
+	}
+	archunit.RegisterProvider(provider)
+	return provider, nil
+}
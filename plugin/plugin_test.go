@@ -0,0 +1,11 @@
+package plugin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	_, err := Load("testdata/absent.so")
+	assert.ErrorContains(t, err, "can not open plugin testdata/absent.so")
+}
@@ -0,0 +1,30 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type orgRules struct{}
+
+func (orgRules) Name() string {
+	return "org"
+}
+
+func (orgRules) Rules() []Rule {
+	return []Rule{NewRule("org naming", func(pkgs ArchPackage) error {
+		return pkgs.NameShould(BeLowerCase)
+	}, "sample/...")}
+}
+
+func TestRuleProvider(t *testing.T) {
+	RegisterProvider(orgRules{})
+	assert.Contains(t, Providers(), "org")
+	rules, err := ProvidedRules("org")
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "org naming", rules[0].Name())
+	assert.NoError(t, Project().Validate(rules...))
+	_, err = ProvidedRules("org", "absent")
+	assert.EqualError(t, err, "can not find rule provider absent")
+}
@@ -0,0 +1,153 @@
+package promote
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// MaxReportedViolations is the number of violations reported for each rule by AssertNoViolations, the rest is
+// summarized as "... and N more"
+var MaxReportedViolations = 20
+
+// goldenPosition matches the source positions in the violations, eg: service.go:12:3, which are not compared with the
+// golden file so the golden file does not change with the unrelated lines
+var goldenPosition = regexp.MustCompile(`(\.[A-Za-z0-9]+):\d+(:\d+)?\b`)
+
+// AssertNoViolations fails the test when the error of Architecture.Validate is not nil, the violations are reported
+// by rule instead of the raw error. with the golden file, one "rule: violation" per line, the known violations are
+// accepted and only the difference to the golden file is reported, "+" for the new ones and "-" for the fixed ones.
+// the violations are compared with the golden file without the source positions and with the paths relative to the
+// module of the golden file, so the golden file is the same on every machine
+func AssertNoViolations(t testing.TB, err error, golden ...string) bool {
+	t.Helper()
+	found := categorize(err)
+	if len(golden) > 0 {
+		expected, rerr := readGolden(golden[0])
+		if rerr != nil {
+			t.Errorf("can not read expected violations %s: %v", golden[0], rerr)
+			return false
+		}
+		root := moduleRoot(golden[0])
+		normalize := func(violations map[string][]string) map[string][]string {
+			return lo.MapValues(violations, func(items []string, _ string) []string {
+				return lo.Map(items, func(violation string, _ int) string {
+					if root != "" {
+						violation = strings.ReplaceAll(violation, root+string(filepath.Separator), "")
+					}
+					return goldenPosition.ReplaceAllString(violation, "$1")
+				})
+			})
+		}
+		if diff := diffViolations(normalize(expected), normalize(found)); len(diff) > 0 {
+			t.Errorf("violations differ from %s:\n%s", golden[0], strings.Join(diff, "\n"))
+			return false
+		}
+		return true
+	}
+	if len(found) == 0 {
+		return true
+	}
+	t.Errorf("architecture violations:\n%s", report(found))
+	return false
+}
+
+// categorize groups the violations of the validation error by the rule name
+func categorize(err error) map[string][]string {
+	found := map[string][]string{}
+	if err == nil {
+		return found
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	lo.ForEach(errs, func(err error, _ int) {
+		rule, message, ok := strings.Cut(err.Error(), ": ")
+		if !ok {
+			rule, message = "", err.Error()
+		}
+		var violations archunit.Violations
+		if errors.As(err, &violations) {
+			found[rule] = append(found[rule], violations...)
+		} else {
+			found[rule] = append(found[rule], message)
+		}
+	})
+	return found
+}
+
+func report(found map[string][]string) string {
+	rules := lo.Keys(found)
+	sort.Strings(rules)
+	var sb strings.Builder
+	lo.ForEach(rules, func(rule string, _ int) {
+		violations := found[rule]
+		sb.WriteString(fmt.Sprintf("%s (%d violations)\n", rule, len(violations)))
+		if n := MaxReportedViolations; n > 0 && len(violations) > n {
+			violations = append(violations[:n:n], fmt.Sprintf("... and %d more", len(violations)-n))
+		}
+		lo.ForEach(violations, func(violation string, _ int) {
+			sb.WriteString(fmt.Sprintf("    %s\n", violation))
+		})
+	})
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func readGolden(file string) (map[string][]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	expected := map[string][]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, violation, _ := strings.Cut(line, ": ")
+		expected[rule] = append(expected[rule], violation)
+	}
+	return expected, nil
+}
+
+// diffViolations returns the new violations prefixed with "+" and the fixed ones prefixed with "-"
+func diffViolations(expected, found map[string][]string) []string {
+	var diff []string
+	rules := lo.Uniq(append(lo.Keys(expected), lo.Keys(found)...))
+	sort.Strings(rules)
+	lo.ForEach(rules, func(rule string, _ int) {
+		fixed, added := lo.Difference(expected[rule], found[rule])
+		diff = append(diff, lo.Map(added, func(violation string, _ int) string {
+			return fmt.Sprintf("+ %s: %s", rule, violation)
+		})...)
+		diff = append(diff, lo.Map(fixed, func(violation string, _ int) string {
+			return fmt.Sprintf("- %s: %s", rule, violation)
+		})...)
+	})
+	return diff
+}
+
+// moduleRoot returns the directory of the go.mod enclosing the file, empty when the file is out of any module
+func moduleRoot(file string) string {
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err = os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
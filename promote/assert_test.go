@@ -0,0 +1,47 @@
+package promote
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAssertNoViolations(t *testing.T) {
+	assert.True(t, AssertNoViolations(t, nil))
+	err := errors.Join(
+		fmt.Errorf("naming: %w", archunit.Violations{"a.go", "b.go", "c.go"}),
+		errors.New("depth: too deep"),
+	)
+	r := &recorder{TB: t}
+	defer func(n int) { MaxReportedViolations = n }(MaxReportedViolations)
+	MaxReportedViolations = 2
+	assert.False(t, AssertNoViolations(r, err))
+	assert.Equal(t, []string{"architecture violations:\ndepth (1 violations)\n    too deep\nnaming (3 violations)\n    a.go\n    b.go\n    ... and 1 more"}, r.errors)
+
+	golden := filepath.Join(t.TempDir(), "violations.txt")
+	assert.NoError(t, os.WriteFile(golden, []byte("# known violations\nnaming: a.go\nnaming: b.go\nnaming: d.go\ndepth: too deep\n"), 0o644))
+	r = &recorder{TB: t}
+	assert.False(t, AssertNoViolations(r, err, golden))
+	assert.Equal(t, []string{fmt.Sprintf("violations differ from %s:\n+ naming: c.go\n- naming: d.go", golden)}, r.errors)
+	assert.True(t, AssertNoViolations(t, errors.Join(fmt.Errorf("naming: %w", archunit.Violations{"a.go", "b.go", "d.go"}), errors.New("depth: too deep")), golden))
+	r = &recorder{TB: t}
+	assert.False(t, AssertNoViolations(r, nil, filepath.Join(t.TempDir(), "missing.txt")))
+	assert.Len(t, r.errors, 1)
+}
+
+func TestAssertNoViolations_Portable(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module fixture\n"), 0o644))
+	golden := filepath.Join(root, "testdata", "violations.txt")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+	assert.NoError(t, os.WriteFile(golden, []byte("ordering: Keys builds keys at service/keys.go\n"), 0o644))
+	// the golden file written on another machine accepts the violations of this one with the shifted lines
+	err := fmt.Errorf("ordering: %w", archunit.Violations{"Keys builds keys at " + filepath.Join(root, "service", "keys.go") + ":12:3"})
+	assert.True(t, AssertNoViolations(t, err, golden))
+	err = fmt.Errorf("ordering: %w", archunit.Violations{"Keys builds keys at " + filepath.Join(root, "model", "keys.go") + ":12:3"})
+	assert.False(t, AssertNoViolations(&recorder{TB: t}, err, golden))
+}
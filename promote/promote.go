@@ -0,0 +1,21 @@
+// Package promote provides the helpers to test the architecture rules themselves, so the rules promoted to the
+// architecture tests are known to fail on violations rather than pass silently
+package promote
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/kcmvp/archunit/archtest"
+	"testing"
+)
+
+// AssertRuleDetects validates the rule against the fixture, a project with violations on purpose, and fails the
+// test when the rule passes. a passing rule on its violation fixture is usually vacuous, eg: the paths of the rule
+// select nothing because of a wrong pattern
+func AssertRuleDetects(t testing.TB, rule archunit.Rule, fixtureDir string) bool {
+	t.Helper()
+	if err := archtest.LoadFixture(t, fixtureDir).Validate(rule); err == nil {
+		t.Errorf("rule '%s' does not detect any violation in %s", rule.Name(), fixtureDir)
+		return false
+	}
+	return true
+}
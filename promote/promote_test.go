@@ -0,0 +1,29 @@
+package promote
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertRuleDetects(t *testing.T) {
+	check := func(pkgs archunit.ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}
+	assert.True(t, AssertRuleDetects(t, archunit.NewRule("name", check, "service"), "testdata/violation"))
+	r := &recorder{TB: t}
+	assert.False(t, AssertRuleDetects(r, archunit.NewRule("name", check, "services"), "testdata/violation"))
+	assert.Equal(t, []string{"rule 'name' does not detect any violation in testdata/violation"}, r.errors)
+}
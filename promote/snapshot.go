@@ -0,0 +1,91 @@
+package promote
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// updateSnapshots rewrites the snapshots instead of comparing them, eg: go test ./... -archunit.update
+var updateSnapshots = flag.Bool("archunit.update", false, "update the architecture snapshots of SnapshotArchitecture")
+
+// SnapshotArchitecture compares the package graph of the architecture, the imports, exported types and exported
+// functions of every package, with the snapshot file and fails the test on any difference, so the structural changes
+// are caught even without explicit rules. the snapshot is created when it does not exist and is rewritten with the
+// flag -archunit.update
+func SnapshotArchitecture(t testing.TB, arch archunit.Architecture, path string) bool {
+	t.Helper()
+	pkgs, _ := arch.Packages("...")
+	actual := snapshot(pkgs)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || *updateSnapshots {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, []byte(strings.Join(actual, "\n")+"\n"), 0o644)
+		}
+		if err != nil {
+			t.Errorf("can not write snapshot %s: %v", path, err)
+			return false
+		}
+		t.Logf("snapshot %s is updated", path)
+		return true
+	} else if err != nil {
+		t.Errorf("can not read snapshot %s: %v", path, err)
+		return false
+	}
+	expected := lo.Compact(strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"))
+	removed, added := lo.Difference(expected, actual)
+	if len(removed)+len(added) == 0 {
+		return true
+	}
+	diff := append(lo.Map(added, func(line string, _ int) string {
+		return "+ " + line
+	}), lo.Map(removed, func(line string, _ int) string {
+		return "- " + line
+	})...)
+	t.Errorf("architecture differs from snapshot %s, run the test with -archunit.update to accept the changes:\n%s",
+		path, strings.Join(diff, "\n"))
+	return false
+}
+
+// snapshot returns the lines of the package graph, one fact per line so the snapshots diff line by line. packages are
+// sorted by the import path and their imports, types and functions are sorted by name, so the snapshot is stable
+// across the runs
+func snapshot(pkgs archunit.ArchPackage) []string {
+	pkgs = append(archunit.ArchPackage{}, pkgs...)
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].ID() < pkgs[j].ID()
+	})
+	var lines []string
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		lines = append(lines, fmt.Sprintf("package %s", pkg.ID()))
+		imports := lo.Uniq(pkg.Imports())
+		sort.Strings(imports)
+		types := lo.FilterMap(pkg.Types(), func(typ internal.Type, _ int) (string, bool) {
+			return typ.Name(), typ.Exported()
+		})
+		sort.Strings(types)
+		functions := lo.FilterMap(pkg.Functions(), func(f internal.Function, _ int) (string, bool) {
+			return f.FullName(), f.Exported()
+		})
+		sort.Strings(functions)
+		lines = append(lines, lo.Map(imports, func(item string, _ int) string {
+			return fmt.Sprintf("import %s -> %s", pkg.ID(), item)
+		})...)
+		lines = append(lines, lo.Map(types, func(item string, _ int) string {
+			return fmt.Sprintf("type %s", item)
+		})...)
+		lines = append(lines, lo.Map(functions, func(item string, _ int) string {
+			return fmt.Sprintf("func %s", item)
+		})...)
+	})
+	return lines
+}
@@ -0,0 +1,48 @@
+package promote
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/kcmvp/archunit/archtest"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotArchitecture(t *testing.T) {
+	arch := archtest.LoadFixture(t, "testdata/violation")
+	path := filepath.Join(t.TempDir(), "arch.snapshot")
+	assert.True(t, SnapshotArchitecture(t, arch, path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"package example.com/violation/controller",
+		"func example.com/violation/controller.Authorized",
+		"package example.com/violation/service",
+		"import example.com/violation/service -> example.com/violation/controller",
+		"func example.com/violation/service.Login",
+	}, strings.Split(strings.TrimSpace(string(data)), "\n"))
+	assert.True(t, SnapshotArchitecture(t, arch, path))
+	changed := strings.Replace(string(data), "service.Login", "service.Logout", 1)
+	assert.NoError(t, os.WriteFile(path, []byte(changed), 0o644))
+	r := &recorder{TB: t}
+	assert.False(t, SnapshotArchitecture(r, arch, path))
+	assert.Len(t, r.errors, 1)
+	assert.True(t, strings.HasSuffix(r.errors[0], "\n+ func example.com/violation/service.Login\n- func example.com/violation/service.Logout"))
+}
+
+func TestSnapshot_KeepOrder(t *testing.T) {
+	pkgs, err := archtest.LoadFixture(t, "testdata/violation").Packages("...")
+	assert.NoError(t, err)
+	reversed := lo.Reverse(append(archunit.ArchPackage{}, pkgs...))
+	ids := lo.Map(reversed, func(pkg *internal.Package, _ int) string {
+		return pkg.ID()
+	})
+	assert.Equal(t, "package example.com/violation/controller", snapshot(reversed)[0])
+	assert.Equal(t, ids, lo.Map(reversed, func(pkg *internal.Package, _ int) string {
+		return pkg.ID()
+	}))
+}
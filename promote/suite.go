@@ -0,0 +1,87 @@
+package promote
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ArchSuite runs the architecture rules as parallel subtests, every rule gets its own *testing.T and the duration of
+// every rule is recorded, so the slow rules of a big suite can be found in the summary
+type ArchSuite struct {
+	arch      archunit.Architecture
+	summary   string
+	slowest   int
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+// NewArchSuite creates the suite validating the rules against the architecture
+func NewArchSuite(arch archunit.Architecture) *ArchSuite {
+	return &ArchSuite{arch: arch, durations: map[string]time.Duration{}}
+}
+
+// WithSummary writes the slowest n rules to the file once all the rules of Run complete, all the rules when n <= 0
+func (suite *ArchSuite) WithSummary(file string, n int) *ArchSuite {
+	suite.summary = file
+	suite.slowest = n
+	return suite
+}
+
+// Run validates each rule in a parallel subtest named after the rule, the subtests start after the calling test returns
+func (suite *ArchSuite) Run(t *testing.T, rules ...archunit.Rule) {
+	t.Helper()
+	if suite.summary != "" {
+		t.Cleanup(func() {
+			if err := suite.writeSummary(); err != nil {
+				t.Errorf("can not write summary %s: %v", suite.summary, err)
+			}
+		})
+	}
+	for _, rule := range rules {
+		t.Run(rule.Name(), func(t *testing.T) {
+			t.Parallel()
+			start := time.Now()
+			err := suite.arch.Validate(rule)
+			suite.mu.Lock()
+			suite.durations[rule.Name()] = time.Since(start)
+			suite.mu.Unlock()
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Durations returns the durations of the rules have run, keyed by the rule name
+func (suite *ArchSuite) Durations() map[string]time.Duration {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	return lo.Assign(suite.durations)
+}
+
+func (suite *ArchSuite) writeSummary() error {
+	durations := suite.Durations()
+	names := lo.Keys(durations)
+	sort.Slice(names, func(i, j int) bool {
+		if durations[names[i]] == durations[names[j]] {
+			return names[i] < names[j]
+		}
+		return durations[names[i]] > durations[names[j]]
+	})
+	if suite.slowest > 0 && len(names) > suite.slowest {
+		names = names[:suite.slowest]
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d rules, %v in total\n", len(durations), lo.Sum(lo.Values(durations))))
+	lo.ForEach(names, func(name string, _ int) {
+		sb.WriteString(fmt.Sprintf("%v\t%s\n", durations[name], name))
+	})
+	return os.WriteFile(suite.summary, []byte(sb.String()), 0o644)
+}
@@ -0,0 +1,36 @@
+package promote
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/kcmvp/archunit/archtest"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchSuite(t *testing.T) {
+	summary := filepath.Join(t.TempDir(), "summary.txt")
+	suite := NewArchSuite(archtest.LoadFixture(t, "testdata/violation")).WithSummary(summary, 2)
+	check := func(pkgs archunit.ArchPackage) error {
+		return pkgs.ShouldNotReferPkgPaths("service")
+	}
+	t.Run("suite", func(t *testing.T) {
+		suite.Run(t,
+			archunit.NewRule("controller", check, "controller"),
+			archunit.NewRule("service", check, "service"),
+			archunit.NewRule("all", func(pkgs archunit.ArchPackage) error {
+				return nil
+			}, "..."),
+		)
+	})
+	durations := suite.Durations()
+	assert.Len(t, durations, 3)
+	assert.Contains(t, durations, "controller")
+	data, err := os.ReadFile(summary)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "3 rules, "))
+}
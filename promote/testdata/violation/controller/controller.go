@@ -0,0 +1,5 @@
+package controller
+
+func Authorized(name string) bool {
+	return name != ""
+}
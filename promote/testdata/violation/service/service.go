@@ -0,0 +1,7 @@
+package svc
+
+import "example.com/violation/controller"
+
+func Login(name string) bool {
+	return controller.Authorized(name)
+}
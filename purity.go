@@ -0,0 +1,102 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// impurePackages is the packages whose functions perform I/O, network or environment access, their sub packages are
+// not included, eg: net/netip
+var impurePackages = []string{"os", "os/exec", "io/ioutil", "net", "syscall", "database/sql"}
+
+// impureFunctions is the functions performing I/O of the packages which are pure otherwise, eg: http.NewServeMux is
+// pure while http.Get is not
+var impureFunctions = []string{
+	"net/http.Get", "net/http.Head", "net/http.Post", "net/http.PostForm",
+	"net/http.ListenAndServe", "net/http.ListenAndServeTLS", "net/http.Serve", "net/http.ServeTLS",
+	"net/http.Client.Do", "net/http.Client.Get", "net/http.Client.Head", "net/http.Client.Post", "net/http.Client.PostForm",
+	"net/http.Server.ListenAndServe", "net/http.Server.ListenAndServeTLS", "net/http.Server.Serve", "net/http.Server.ServeTLS",
+}
+
+// PackageInitializationShouldBePure checks the package level variable initializers and the init functions of the
+// packages do not perform I/O, network, environment reads or start goroutines, so program startup is deterministic.
+// function literals are not executed by the initialization and are ignored
+func PackageInitializationShouldBePure(pkgs ArchPackage) error {
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		arch := Architecture{artifact: pkg.Artifact()}
+		report := func(scope string, obj types.Object, node ast.Node) {
+			if arch.ignored("PackageInitializationShouldBePure", obj) {
+				return
+			}
+			ast.Inspect(node, func(node ast.Node) bool {
+				switch n := node.(type) {
+				case *ast.FuncLit:
+					return false
+				case *ast.GoStmt:
+					position := pkg.Raw().Fset.Position(n.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf(localize("%s starts goroutine at %s"), scope, position)))
+				case *ast.CallExpr:
+					if f := calledFunc(n, info); f != nil && f.Pkg() != nil && impure(f) {
+						position := pkg.Raw().Fset.Position(n.Pos())
+						result = append(result, violationAt(position, fmt.Sprintf(localize("%s calls %s at %s"), scope, funcName(f), position)))
+					}
+				}
+				return true
+			})
+		}
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Recv == nil && d.Name.Name == "init" && d.Body != nil {
+						report(fmt.Sprintf("%s.init", pkg.ID()), info.Defs[d.Name], d.Body)
+					}
+				case *ast.GenDecl:
+					if d.Tok == token.VAR {
+						lo.ForEach(d.Specs, func(spec ast.Spec, _ int) {
+							vs := spec.(*ast.ValueSpec)
+							// a single multi-valued initializer, eg: `var a, b = f()`, initializes all the names
+							names := lo.Map(vs.Names, func(name *ast.Ident, _ int) string {
+								return name.Name
+							})
+							lo.ForEach(vs.Values, func(value ast.Expr, i int) {
+								if len(vs.Values) == len(vs.Names) {
+									report(fmt.Sprintf("%s.%s", pkg.ID(), names[i]), info.Defs[vs.Names[i]], value)
+								} else {
+									report(fmt.Sprintf("%s.%s", pkg.ID(), strings.Join(names, ", ")), info.Defs[vs.Names[0]], value)
+								}
+							})
+						})
+					}
+				}
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("package initialization is not pure: %w"), result.sorted())).Else(nil)
+}
+
+// calledFunc returns the function or method called by the expression, nil for builtins, conversions and dynamic calls
+func calledFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	f, _ := info.Uses[id].(*types.Func)
+	return f
+}
+
+func impure(f *types.Func) bool {
+	return lo.Contains(impurePackages, f.Pkg().Path()) || lo.Contains(impureFunctions, funcName(f))
+}
@@ -0,0 +1,27 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPackageInitializationShouldBePure(t *testing.T) {
+	pkgs, err := Packages("sample/...")
+	assert.NoError(t, err)
+	assert.NoError(t, PackageInitializationShouldBePure(pkgs))
+	arch, err := Load("testdata/impure")
+	assert.NoError(t, err)
+	pkgs, err = arch.Packages("impure")
+	assert.NoError(t, err)
+	err = PackageInitializationShouldBePure(pkgs)
+	assert.Error(t, err)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 6)
+	assert.Contains(t, violations[0], "example.com/impure.home calls os.Getenv at ")
+	assert.Contains(t, violations[1], "example.com/impure.init calls os.ReadFile at ")
+	assert.Contains(t, violations[2], "example.com/impure.init starts goroutine at ")
+	assert.Contains(t, violations[3], "example.com/impure.resp, respErr calls net/http.Get at ")
+	assert.Contains(t, violations[4], "example.com/impure.user calls os.Getenv at ")
+	assert.Contains(t, violations[5], "example.com/impure.wd, wdErr calls os.Getwd at ")
+}
@@ -0,0 +1,177 @@
+package archunit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Violations is the violations found by a rule. rules wrap it in their errors, eg: fmt.Errorf("... %w", Violations(result)),
+// so the report of Architecture.Validate can be trimmed by the report options. the rules locating the violations in the
+// source wrap Findings instead
+type Violations []string
+
+func (violations Violations) Error() string {
+	return fmt.Sprintf("%v", []string(violations))
+}
+
+// RuleResult is the result of a rule checked by Architecture.ValidateWithReport, the violations of a failed rule are
+// all the violations found while its error is trimmed by the report options, a failed rule without Violations has its
+// error message as the only violation
+type RuleResult struct {
+	Rule       string
+	Category   string
+	Violations []Violation
+	Err        error
+	// Cached reports the result is restored from the ResultCache of the architecture rather than checked
+	Cached bool
+}
+
+// Reporter writes the results of the rules checked by Architecture.ValidateWithReport in a format, eg: SARIF
+type Reporter interface {
+	Report(arch Architecture, results []RuleResult) error
+}
+
+// ReportOption controls the noise of the report of Architecture.Validate
+type ReportOption func(report *reportOptions)
+
+type reportOptions struct {
+	maxViolations int
+	collapse      bool
+	maxLines      int
+	ownership     Ownership
+	summary       *summaryOptions
+}
+
+type summaryOptions struct {
+	top  int
+	file string
+}
+
+// MaxViolationsPerRule reports at most n violations for each rule, the rest is summarized as "... and N more"
+func MaxViolationsPerRule(n int) ReportOption {
+	return func(report *reportOptions) {
+		report.maxViolations = n
+	}
+}
+
+// CollapseByPackage reports the number of violations of each package instead of the violations themselves
+func CollapseByPackage() ReportOption {
+	return func(report *reportOptions) {
+		report.collapse = true
+	}
+}
+
+// MaxReportLines reports at most n lines in total, the rest is summarized as "... and N more"
+func MaxReportLines(n int) ReportOption {
+	return func(report *reportOptions) {
+		report.maxLines = n
+	}
+}
+
+// Summary reports the number of violations of each category and the top n violations only, the full list of the
+// violations is written to the file, one line per violation, so the digest is not truncated by the CI logs. the file
+// is not written when it is empty
+func Summary(top int, file string) ReportOption {
+	return func(report *reportOptions) {
+		report.summary = &summaryOptions{top: top, file: file}
+	}
+}
+
+// With returns the architecture reporting with the options
+func (arch Architecture) With(options ...ReportOption) Architecture {
+	lo.ForEach(options, func(option ReportOption, _ int) {
+		option(&arch.report)
+	})
+	return arch
+}
+
+// trim applies the report options on the violations of a rule
+func (arch Architecture) trim(violations []Violation) []Violation {
+	if arch.report.collapse {
+		violations = arch.collapse(violations)
+	}
+	if n := arch.report.maxViolations; n > 0 && len(violations) > n {
+		violations = append(violations[:n:n], Violation{Message: fmt.Sprintf(localize("... and %d more"), len(violations)-n)})
+	}
+	return violations
+}
+
+// collapse counts the violations by the package they mention, by import path or by directory. the violations
+// without any package are kept as they are
+func (arch Architecture) collapse(violations []Violation) []Violation {
+	counts := map[string]int{}
+	var others []Violation
+	lo.ForEach(violations, func(violation Violation, _ int) {
+		if pkg := arch.mentioned(violation.Message); pkg != nil {
+			counts[pkg.ID()]++
+		} else {
+			others = append(others, violation)
+		}
+	})
+	collapsed := lo.MapToSlice(counts, func(pkg string, n int) Violation {
+		return Violation{Message: fmt.Sprintf(localize("%s (%d violations)"), pkg, n)}
+	})
+	sort.Slice(collapsed, func(i, j int) bool {
+		return collapsed[i].Message < collapsed[j].Message
+	})
+	return append(collapsed, others...)
+}
+
+// mentioned returns the innermost package the violation mentions by import path or by directory, returns nil when
+// the violation mentions no package
+func (arch Architecture) mentioned(violation string) *internal.Package {
+	pkgs := lo.Filter(arch.artifact.Packages(), func(pkg *internal.Package, _ int) bool {
+		return strings.Contains(violation, pkg.ID()) ||
+			len(pkg.GoFiles()) > 0 && strings.Contains(violation, filepath.Dir(pkg.GoFiles()[0])+string(filepath.Separator))
+	})
+	if len(pkgs) == 0 {
+		return nil
+	}
+	return lo.MaxBy(pkgs, func(a, b *internal.Package) bool {
+		return len(a.ID()) > len(b.ID())
+	})
+}
+
+// summarize returns the digest of the failed rules by the summary options and writes their violations to the file
+func (arch Architecture) summarize(results []RuleResult) error {
+	var violations []string
+	counts := map[string]int{}
+	lo.ForEach(results, func(result RuleResult, _ int) {
+		lo.ForEach(result.Violations, func(violation Violation, _ int) {
+			violations = append(violations, fmt.Sprintf("%s: %s", result.Rule, violation.Message))
+			counts[lo.If(result.Category == "", "uncategorized").Else(result.Category)]++
+		})
+	})
+	if len(violations) == 0 {
+		return nil
+	}
+	categories := lo.Keys(counts)
+	sort.Strings(categories)
+	lines := []string{fmt.Sprintf(localize("%d violations of %d rules"), len(violations), lo.CountBy(results, func(result RuleResult) bool {
+		return result.Err != nil
+	}))}
+	lines = append(lines, lo.Map(categories, func(category string, _ int) string {
+		return fmt.Sprintf("  %s: %d", category, counts[category])
+	})...)
+	if stats, ok := arch.CacheStats(); ok {
+		lines = append(lines, stats.String())
+	}
+	top := lo.Subset(violations, 0, uint(lo.Max([]int{arch.report.summary.top, 0})))
+	lines = append(lines, fmt.Sprintf(localize("top %d violations:"), len(top)))
+	lines = append(lines, lo.Map(top, func(violation string, _ int) string {
+		return "  " + violation
+	})...)
+	if file := arch.report.summary.file; file != "" {
+		if err := os.WriteFile(file, []byte(strings.Join(violations, "\n")+"\n"), 0o644); err != nil {
+			return errors.Join(errors.New(strings.Join(lines, "\n")), fmt.Errorf(localize("can not write %s: %w"), file, err))
+		}
+		lines = append(lines, fmt.Sprintf(localize("full report: %s"), file))
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
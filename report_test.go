@@ -0,0 +1,79 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestViolations(t *testing.T) {
+	err := fmt.Errorf("exported functions panic %w", Violations{"a", "b"})
+	assert.Equal(t, "exported functions panic [a b]", err.Error())
+}
+
+func TestArchitecture_With(t *testing.T) {
+	many := NewRule("many", func(pkgs ArchPackage) error {
+		return fmt.Errorf("violations %w found", Violations{
+			"github.com/kcmvp/archunit/internal/sample/service.A",
+			"github.com/kcmvp/archunit/internal/sample/service/ext.B",
+			"github.com/kcmvp/archunit/internal/sample/service.C",
+			"unknown",
+		})
+	}, "sample/service")
+	panics := NewRule("panic", func(pkgs ArchPackage) error {
+		return ExportedFunctionsShouldNotPanic(pkgs.Types().Methods())
+	}, "sample/service")
+	plain := NewRule("plain", func(pkgs ArchPackage) error {
+		return fmt.Errorf("plain error")
+	}, "sample/service")
+	arch := Project()
+	err := arch.With(MaxViolationsPerRule(2)).Validate(many)
+	assert.Equal(t, "many: violations [github.com/kcmvp/archunit/internal/sample/service.A github.com/kcmvp/archunit/internal/sample/service/ext.B ... and 2 more] found", err.Error())
+	err = arch.With(CollapseByPackage()).Validate(many)
+	assert.Equal(t, "many: violations [github.com/kcmvp/archunit/internal/sample/service (2 violations) github.com/kcmvp/archunit/internal/sample/service/ext (1 violations) unknown] found", err.Error())
+	err = arch.With(CollapseByPackage()).Validate(panics)
+	assert.Equal(t, "panic: exported functions panic [github.com/kcmvp/archunit/internal/sample/service (8 violations)]", err.Error())
+	err = arch.With(MaxReportLines(2)).Validate(many, panics, plain)
+	lines := strings.Split(err.Error(), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "... and 1 more", lines[2])
+	assert.Equal(t, "plain: plain error", arch.With(MaxViolationsPerRule(1), CollapseByPackage()).Validate(plain).Error())
+	assert.Len(t, strings.Split(arch.Validate(many, panics, plain).Error(), "\n"), 3)
+}
+
+func TestSummary(t *testing.T) {
+	many := NewRule("many", func(pkgs ArchPackage) error {
+		return fmt.Errorf("found %w", Violations{"a", "b", "c"})
+	}).WithCategory("naming")
+	plain := NewRule("plain", func(pkgs ArchPackage) error {
+		return fmt.Errorf("plain error")
+	})
+	passed := NewRule("passed", func(pkgs ArchPackage) error {
+		return nil
+	}).WithCategory("layer")
+	file := filepath.Join(t.TempDir(), "violations.txt")
+	err := Project().With(Summary(2, file)).Validate(many, plain, passed)
+	assert.Equal(t, `4 violations of 2 rules
+  naming: 3
+  uncategorized: 1
+top 2 violations:
+  many: a
+  many: b
+full report: `+file, err.Error())
+	content, _ := os.ReadFile(file)
+	assert.Equal(t, "many: a\nmany: b\nmany: c\nplain: plain error\n", string(content))
+	err = Project().With(Summary(0, "")).Validate(many)
+	assert.Equal(t, "3 violations of 1 rules\n  naming: 3\ntop 0 violations:", err.Error())
+	// the summary counts and writes the violations before they are trimmed
+	err = Project().With(Summary(1, file), MaxViolationsPerRule(1), CollapseByPackage()).Validate(many)
+	assert.True(t, strings.HasPrefix(err.Error(), "3 violations of 1 rules\n  naming: 3\n"))
+	content, _ = os.ReadFile(file)
+	assert.Equal(t, "many: a\nmany: b\nmany: c\n", string(content))
+	assert.NoError(t, Project().With(Summary(2, file)).Validate(passed))
+	err = Project().With(Summary(1, filepath.Join(file, "missing.txt"))).Validate(plain)
+	assert.Contains(t, err.Error(), "1 violations of 1 rules")
+	assert.Contains(t, err.Error(), "can not write")
+}
@@ -0,0 +1,126 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// resourceOpeners are the functions open resources and the selector path to close the resource
+var resourceOpeners = map[string][]string{
+	"os.Open":                          {"Close"},
+	"os.Create":                        {"Close"},
+	"os.OpenFile":                      {"Close"},
+	"net/http.Get":                     {"Body", "Close"},
+	"net/http.Head":                    {"Body", "Close"},
+	"net/http.Post":                    {"Body", "Close"},
+	"net/http.PostForm":                {"Body", "Close"},
+	"net/http.Client.Do":               {"Body", "Close"},
+	"net/http.Client.Get":              {"Body", "Close"},
+	"net/http.Client.Head":             {"Body", "Close"},
+	"net/http.Client.Post":             {"Body", "Close"},
+	"net/http.Client.PostForm":         {"Body", "Close"},
+	"database/sql.DB.Query":            {"Close"},
+	"database/sql.DB.QueryContext":     {"Close"},
+	"database/sql.Tx.Query":            {"Close"},
+	"database/sql.Tx.QueryContext":     {"Close"},
+	"database/sql.Conn.QueryContext":   {"Close"},
+	"database/sql.Stmt.Query":          {"Close"},
+	"database/sql.Stmt.QueryContext":   {"Close"},
+	"database/sql.DB.Prepare":          {"Close"},
+	"database/sql.DB.PrepareContext":   {"Close"},
+	"database/sql.Tx.Prepare":          {"Close"},
+	"database/sql.Tx.PrepareContext":   {"Close"},
+	"database/sql.Conn.PrepareContext": {"Close"},
+}
+
+// OpenedResourcesShouldBeClosed checks the resources opened by the common standard library functions, such as os.Open,
+// http.Get(Response.Body) and sql.DB.Query(sql.Rows), are closed with defer in the same function. a resource returned
+// to the caller or stored in a field is not checked as the ownership is transferred
+func OpenedResourcesShouldBeClosed() error {
+	return Project().OpenedResourcesShouldBeClosed()
+}
+
+// OpenedResourcesShouldBeClosed checks the opened resources of the architecture, see the top level one
+func (arch Architecture) OpenedResourcesShouldBeClosed() error {
+	var result Findings
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Body == nil || arch.ignored("OpenedResourcesShouldBeClosed", info.Defs[fd.Name]) {
+					return
+				}
+				ast.Inspect(fd.Body, func(node ast.Node) bool {
+					assign, ok := node.(*ast.AssignStmt)
+					if !ok || len(assign.Rhs) != 1 {
+						return true
+					}
+					call, ok := assign.Rhs[0].(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					callee, ok := typeutil.Callee(info, call).(*types.Func)
+					if !ok {
+						return true
+					}
+					if closer, ok := resourceOpeners[funcName(callee)]; ok {
+						id, ok := assign.Lhs[0].(*ast.Ident)
+						if !ok {
+							// the resource is stored in a field or an element, the owner closes it
+							return true
+						}
+						if res := info.ObjectOf(id); res == nil || !closedOrReturned(fd.Body, info, res, closer) {
+							position := pkg.Raw().Fset.Position(call.Pos())
+							result = append(result, violationAt(position, fmt.Sprintf(localize("%s at %s"), funcName(callee), position)))
+						}
+					}
+					return true
+				})
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("resources are not closed: %w"), result.sorted())).Else(nil)
+}
+
+// closedOrReturned checks whether the resource is closed by a defer statement or returned by the function
+func closedOrReturned(body *ast.BlockStmt, info *types.Info, res types.Object, closer []string) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.DeferStmt:
+			ast.Inspect(n, func(node ast.Node) bool {
+				if call, ok := node.(*ast.CallExpr); ok && selects(call.Fun, info, res, closer) {
+					found = true
+				}
+				return !found
+			})
+		case *ast.ReturnStmt:
+			lo.ForEach(n.Results, func(expr ast.Expr, _ int) {
+				if id, ok := expr.(*ast.Ident); ok && info.Uses[id] == res {
+					found = true
+				}
+			})
+		}
+		return !found
+	})
+	return found
+}
+
+// selects checks whether the expression is the selector path of the object, eg: resp.Body.Close
+func selects(expr ast.Expr, info *types.Info, obj types.Object, path []string) bool {
+	for i := len(path) - 1; i >= 0; i-- {
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != path[i] {
+			return false
+		}
+		expr = sel.X
+	}
+	id, ok := expr.(*ast.Ident)
+	return ok && info.Uses[id] == obj
+}
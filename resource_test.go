@@ -0,0 +1,24 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestOpenedResourcesShouldBeClosed(t *testing.T) {
+	err := OpenedResourcesShouldBeClosed()
+	assert.Error(t, err)
+	assert.Equal(t, 1, strings.Count(err.Error(), " at "))
+	assert.Contains(t, err.Error(), "os.Open at ")
+	assert.Contains(t, err.Error(), "sample/service/thirdparty/local.go:24")
+}
+
+func TestOpenedResourcesShouldBeClosed_Field(t *testing.T) {
+	arch, err := Load("testdata/resource")
+	assert.NoError(t, err)
+	var violations Violations
+	assert.ErrorAs(t, arch.OpenedResourcesShouldBeClosed(), &violations)
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "resource/resource.go:20:12")
+}
@@ -0,0 +1,45 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"regexp"
+)
+
+// RouteFunctions is the functions registering http routes, named as CallersOf. it covers net/http, gin, echo and chi
+// by default, append the functions of other routers to it
+var RouteFunctions = lo.Flatten([][]string{
+	{"net/http.Handle", "net/http.HandleFunc", "net/http.ServeMux.Handle", "net/http.ServeMux.HandleFunc"},
+	methodsOf("github.com/gin-gonic/gin.RouterGroup", "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "Any", "Handle"),
+	methodsOf("github.com/labstack/echo/v4.Echo", "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "Any", "Add"),
+	methodsOf("github.com/labstack/echo/v4.Group", "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "Any", "Add"),
+	methodsOf("github.com/go-chi/chi/v5.Mux", "Get", "Post", "Put", "Patch", "Delete", "Head", "Options", "Handle", "HandleFunc", "Method", "MethodFunc"),
+})
+
+func methodsOf(typ string, methods ...string) []string {
+	return lo.Map(methods, func(method string, _ int) string {
+		return fmt.Sprintf("%s.%s", typ, method)
+	})
+}
+
+// HTTPRoutesShouldBeRegisteredIn checks the http routes are only registered by the calls of RouteFunctions
+// in the packages of the paths, so the endpoints are not scattered across the project
+func HTTPRoutesShouldBeRegisteredIn(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	var result Findings
+	lo.ForEach(CallersOf(RouteFunctions...), func(site internal.CallSite, _ int) {
+		if caller, ok := site.Caller(); ok && ignoredFunction("HTTPRoutesShouldBeRegisteredIn", caller) {
+			return
+		}
+		if lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(site.Package().ID())
+		}) {
+			result = append(result, violationAt(site.Position(), callSite(site)))
+		}
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("http routes are registered out of %v: %w"), paths, result.sorted())).Else(nil)
+}
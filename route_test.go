@@ -0,0 +1,18 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestHTTPRoutesShouldBeRegisteredIn(t *testing.T) {
+	assert.NoError(t, HTTPRoutesShouldBeRegisteredIn("sample/controller/..."))
+	err := HTTPRoutesShouldBeRegisteredIn("sample/controller")
+	assert.Error(t, err)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "(github.com/kcmvp/archunit/internal/sample/controller/module1.AppController).register -> (*net/http.ServeMux).Handle"))
+	assert.Error(t, HTTPRoutesShouldBeRegisteredIn("sample/[a"))
+}
@@ -0,0 +1,149 @@
+// Package sarif reports the results of the architecture rules in SARIF 2.1.0, so the violations can be uploaded to
+// the code scanning services, eg: GitHub code scanning
+package sarif
+
+import (
+	"encoding/json"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Version is the version of the SARIF specification of the reports
+const Version = "2.1.0"
+
+// Schema is the json schema of the reports
+const Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// srcRoot is the base of the artifact locations, the locations are relative to the root of the architecture
+const srcRoot = "%SRCROOT%"
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool       Tool           `json:"tool"`
+	Results    []Result       `json:"results"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule is the reporting descriptor of an architecture rule, the id is the name of the rule
+type Rule struct {
+	ID               string         `json:"id"`
+	ShortDescription Message        `json:"shortDescription"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a violation of a rule
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// Reporter writes the results of the rules as a SARIF log
+type Reporter struct {
+	w io.Writer
+}
+
+// NewReporter creates the reporter writing to w, eg:
+// arch.ValidateWithReport(sarif.NewReporter(file), rules...)
+func NewReporter(w io.Writer) *Reporter {
+	return &Reporter{w: w}
+}
+
+func (reporter *Reporter) Report(arch archunit.Architecture, results []archunit.RuleResult) error {
+	encoder := json.NewEncoder(reporter.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Convert(arch, results))
+}
+
+// Convert converts the results to the SARIF log, every rule is a reporting descriptor and every violation is a result
+// of the rule. violations are located by their source positions, relative to the root of the architecture,
+// the violations without source positions are located on go.mod. every violation found is a result, the report
+// options of the architecture trim the error of ValidateWithReport only. the stats of the cache of the architecture, if any,
+// are the properties of the run
+func Convert(arch archunit.Architecture, results []archunit.RuleResult) Log {
+	run := Run{
+		Tool: Tool{Driver: Driver{
+			Name:           "archunit",
+			InformationURI: "https://github.com/kcmvp/archunit",
+			Rules: lo.Map(results, func(result archunit.RuleResult, _ int) Rule {
+				return Rule{
+					ID:               result.Rule,
+					ShortDescription: Message{Text: result.Rule},
+					Properties: lo.If(result.Category != "", map[string]any{"category": result.Category}).
+						Else(nil),
+				}
+			}),
+		}},
+		Results: []Result{},
+	}
+	lo.ForEach(results, func(result archunit.RuleResult, i int) {
+		lo.ForEach(result.Violations, func(violation archunit.Violation, _ int) {
+			run.Results = append(run.Results, Result{
+				RuleID:    result.Rule,
+				RuleIndex: i,
+				Level:     "error",
+				Message:   Message{Text: violation.Message},
+				Locations: []Location{locate(arch.RootDir(), violation)},
+			})
+		})
+	})
+	if stats, ok := arch.CacheStats(); ok {
+		run.Properties = map[string]any{"cacheHits": stats.Hits, "cacheMisses": stats.Misses}
+	}
+	return Log{Schema: Schema, Version: Version, Runs: []Run{run}}
+}
+
+func locate(root string, violation archunit.Violation) Location {
+	location := PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: "go.mod", URIBaseID: srcRoot}}
+	if file := violation.File; file != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+		location.ArtifactLocation.URI = filepath.ToSlash(file)
+		location.Region = &Region{StartLine: violation.Line, StartColumn: violation.Column}
+	}
+	return Location{PhysicalLocation: location}
+}
@@ -0,0 +1,78 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/kcmvp/archunit"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReporter(t *testing.T) {
+	ordering := archunit.NewRule("ordering", func(pkgs archunit.ArchPackage) error {
+		return pkgs.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+	}, "...").WithCategory("package")
+	naming := archunit.NewRule("naming", func(pkgs archunit.ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views")
+	passed := archunit.NewRule("passed", func(pkgs archunit.ArchPackage) error {
+		return nil
+	}, "...")
+	var buf bytes.Buffer
+	err := archunit.Project().ValidateWithReport(NewReporter(&buf), ordering, naming, passed)
+	assert.Error(t, err)
+	assert.Equal(t, archunit.Project().Validate(ordering, naming, passed).Error(), err.Error())
+	var log Log
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, Version, log.Version)
+	assert.Equal(t, Schema, log.Schema)
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, []Rule{
+		{ID: "ordering", ShortDescription: Message{Text: "ordering"}, Properties: map[string]any{"category": "package"}},
+		{ID: "naming", ShortDescription: Message{Text: "naming"}},
+		{ID: "passed", ShortDescription: Message{Text: "passed"}},
+	}, run.Tool.Driver.Rules)
+	assert.Len(t, run.Results, 2)
+	assert.Equal(t, Location{PhysicalLocation: PhysicalLocation{
+		ArtifactLocation: ArtifactLocation{URI: "internal/sample/flags/flags.go", URIBaseID: "%SRCROOT%"},
+		Region:           &Region{StartLine: 27, StartColumn: 4},
+	}}, run.Results[0].Locations[0])
+	assert.Equal(t, 0, run.Results[0].RuleIndex)
+	assert.Equal(t, "naming", run.Results[1].RuleID)
+	assert.Equal(t, 1, run.Results[1].RuleIndex)
+	assert.Equal(t, "go.mod", run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region)
+}
+
+func TestConvert_CacheStats(t *testing.T) {
+	naming := archunit.NewRule("naming", func(pkgs archunit.ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views")
+	arch := archunit.Project()
+	assert.Nil(t, Convert(arch, nil).Runs[0].Properties)
+	arch = arch.WithCache(archunit.NewResultCache(t.TempDir()))
+	var buf bytes.Buffer
+	assert.Error(t, arch.ValidateWithReport(NewReporter(&buf), naming))
+	var log Log
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, map[string]any{"cacheHits": float64(0), "cacheMisses": float64(1)}, log.Runs[0].Properties)
+}
+
+func TestReporter_Trimmed(t *testing.T) {
+	many := archunit.NewRule("many", func(pkgs archunit.ArchPackage) error {
+		return fmt.Errorf("found: %w", archunit.Violations{"a", "b", "c"})
+	})
+	var buf bytes.Buffer
+	arch := archunit.Project().With(archunit.MaxViolationsPerRule(1))
+	err := arch.ValidateWithReport(NewReporter(&buf), many)
+	assert.EqualError(t, err, "many: found: [a ... and 2 more]")
+	var log Log
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	// the results are the violations rather than the summary of the trimmed ones
+	assert.Equal(t, []string{"a", "b", "c"}, lo.Map(log.Runs[0].Results, func(result Result, _ int) string {
+		return result.Message.Text
+	}))
+}
@@ -13,12 +13,12 @@ func ScopePattern(paths ...string) ([]*regexp.Regexp, error) {
 		path := strings.TrimPrefix(strings.TrimSuffix(item, "/"), "/")
 		return lo.Union([]string{path, strings.TrimSuffix(path, "/...")})
 	})
-	pattern := `^(?:[a-zA-Z]+(?:\.[a-zA-Z]+)*|\.\.\.)$`
+	pattern := `^(?:[a-zA-Z0-9_\-]+(?:\.[a-zA-Z0-9_\-]+)*|\.\.\.)$`
 	re := regexp.MustCompile(pattern)
 	for _, path := range pps {
 		for _, seg := range strings.Split(path, "/") {
 			if len(seg) > 0 && !re.MatchString(seg) {
-				return nil, fmt.Errorf("invalid package paths: %s", path)
+				return nil, fmt.Errorf(localize("invalid package paths: %s"), path)
 			}
 		}
 	}
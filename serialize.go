@@ -0,0 +1,50 @@
+package archunit
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// artifactVersion is the version of the format written by Architecture.Save, LoadArtifact rejects the other versions
+var artifactVersion = 1
+
+// savedArtifact is the format of Architecture.Save, the package index with the sources embedded
+type savedArtifact struct {
+	Version int          `json:"version"`
+	Index   PackageIndex `json:"index"`
+}
+
+// Save writes the architecture to w as the gzipped json of its package index with the sources embedded, the
+// architecture is loaded by LoadArtifact on the machines without the sources, eg: the build farm parses the project
+// once and the governance jobs validate the rule sets against the saved artifact
+func (arch Architecture) Save(w io.Writer) error {
+	index, err := arch.Index(true)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(w)
+	if err = json.NewEncoder(zw).Encode(savedArtifact{Version: artifactVersion, Index: index}); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// LoadArtifact loads the architecture saved by Architecture.Save, see LoadIndex for the limitations of the loaded
+// architecture
+func LoadArtifact(r io.Reader) (Architecture, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return Architecture{}, fmt.Errorf(localize("invalid artifact: %w"), err)
+	}
+	defer zr.Close()
+	var saved savedArtifact
+	if err = json.NewDecoder(zr).Decode(&saved); err != nil {
+		return Architecture{}, fmt.Errorf(localize("invalid artifact: %w"), err)
+	}
+	if saved.Version != artifactVersion {
+		return Architecture{}, fmt.Errorf(localize("artifact version %d is not supported, expected %d"), saved.Version, artifactVersion)
+	}
+	return LoadIndex(saved.Index)
+}
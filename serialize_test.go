@@ -0,0 +1,43 @@
+package archunit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArchitecture_Save(t *testing.T) {
+	arch, err := Load("testdata/cycle")
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, arch.Save(&buf))
+	loaded, err := LoadArtifact(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, arch.RootDir(), loaded.RootDir())
+	pkgs, err := loaded.Packages("cycle/model", "cycle/service")
+	assert.NoError(t, err)
+	assert.Error(t, pkgs.ShouldBeFreeOfCycles())
+	// the calls of the standard library are typed by the export data saved along with the sources
+	arch, err = Load("testdata/impure")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, arch.Save(&buf))
+	loaded, err = LoadArtifact(&buf)
+	assert.NoError(t, err)
+	pkgs, err = loaded.Packages("impure")
+	assert.NoError(t, err)
+	var violations Violations
+	assert.ErrorAs(t, PackageInitializationShouldBePure(pkgs), &violations)
+	assert.Len(t, violations, 6)
+
+	_, err = LoadArtifact(bytes.NewReader([]byte("{}")))
+	assert.ErrorContains(t, err, "invalid artifact")
+	buf.Reset()
+	zw := gzip.NewWriter(&buf)
+	assert.NoError(t, json.NewEncoder(zw).Encode(savedArtifact{Version: artifactVersion + 1}))
+	assert.NoError(t, zw.Close())
+	_, err = LoadArtifact(&buf)
+	assert.EqualError(t, err, "artifact version 2 is not supported, expected 1")
+}
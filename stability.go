@@ -0,0 +1,115 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/types"
+)
+
+// StableAPIShouldNotDependOnExperimental checks the declarations marked as `//archunit:stability=stable` do not refer
+// to any declarations marked as `//archunit:stability=experimental`, neither in the signature nor in the body.
+// methods inherit the stability of the receiver type, the directive on the package clause applies to the whole package
+func StableAPIShouldNotDependOnExperimental() error {
+	return Project().StableAPIShouldNotDependOnExperimental()
+}
+
+// StableAPIShouldNotDependOnExperimental checks the stable declarations of the architecture, see the top level one
+func (arch Architecture) StableAPIShouldNotDependOnExperimental() error {
+	var result []string
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		info := pkg.Raw().TypesInfo
+		check := func(obj types.Object, nodes ...ast.Node) {
+			if arch.stability(obj) != "stable" || arch.ignored("StableAPIShouldNotDependOnExperimental", obj) {
+				return
+			}
+			lo.ForEach(nodes, func(node ast.Node, _ int) {
+				ast.Inspect(node, func(ref ast.Node) bool {
+					if id, ok := ref.(*ast.Ident); ok {
+						if used := info.Uses[id]; arch.stability(used) == "experimental" {
+							result = append(result, fmt.Sprintf("%s -> %s", qualifiedName(obj), qualifiedName(used)))
+						}
+					}
+					return true
+				})
+			})
+		}
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			ast.Inspect(file, func(node ast.Node) bool {
+				switch n := node.(type) {
+				case *ast.FuncDecl:
+					check(info.Defs[n.Name], n)
+				case *ast.TypeSpec:
+					check(info.Defs[n.Name], n)
+				case *ast.ValueSpec:
+					// every name of the spec has the stability of the spec, and refers to the type and its own value,
+					// or to the single multi-valued initializer, eg: `var a, b = f()`
+					lo.ForEach(n.Names, func(name *ast.Ident, i int) {
+						var nodes []ast.Node
+						if n.Type != nil {
+							nodes = append(nodes, n.Type)
+						}
+						if len(n.Values) == len(n.Names) {
+							nodes = append(nodes, n.Values[i])
+						} else {
+							nodes = append(nodes, lo.Map(n.Values, func(value ast.Expr, _ int) ast.Node {
+								return value
+							})...)
+						}
+						check(info.Defs[name], nodes...)
+					})
+				default:
+					return true
+				}
+				return false
+			})
+		})
+	})
+	result = lo.Uniq(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("stable api depends on experimental api: %w"), Violations(result))).Else(nil)
+}
+
+// stability returns the stability directive of the package-level object or the method, falls back to the receiver
+// type for methods and then to the package clause. the locals, the parameters and the fields have no stability
+func (arch Architecture) stability(obj types.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+	if f, ok := obj.(*types.Func); !ok || f.Type().(*types.Signature).Recv() == nil {
+		if obj.Parent() != obj.Pkg().Scope() {
+			return ""
+		}
+	}
+	pkg := arch.artifact.Package(obj.Pkg().Path())
+	if pkg == nil {
+		return ""
+	}
+	if values := pkg.Directive(obj, "stability"); len(values) > 0 {
+		return values[len(values)-1]
+	}
+	if f, ok := obj.(*types.Func); ok {
+		if recv := f.Type().(*types.Signature).Recv(); recv != nil {
+			typ := recv.Type()
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			if named, ok := typ.(*types.Named); ok {
+				if values := pkg.Directive(named.Obj(), "stability"); len(values) > 0 {
+					return values[len(values)-1]
+				}
+			}
+		}
+	}
+	if values := pkg.PackageDirective("stability"); len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return ""
+}
+
+func qualifiedName(obj types.Object) string {
+	if f, ok := obj.(*types.Func); ok {
+		return f.FullName()
+	}
+	return fmt.Sprintf("%s.%s", obj.Pkg().Path(), obj.Name())
+}
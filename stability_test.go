@@ -0,0 +1,32 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStableAPIShouldNotDependOnExperimental(t *testing.T) {
+	err := StableAPIShouldNotDependOnExperimental()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sample/service.auditLog -> github.com/kcmvp/archunit/internal/sample/service.Audit")
+	assert.NotContains(t, err.Error(), "AuditCall")
+	assert.NotContains(t, err.Error(), "sample/model")
+}
+
+func TestStableAPIShouldNotDependOnExperimental_Names(t *testing.T) {
+	arch, err := Load("testdata/stability")
+	assert.NoError(t, err)
+	err = arch.StableAPIShouldNotDependOnExperimental()
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.ElementsMatch(t, Violations{
+		"example.com/stability.Retries -> example.com/stability.Beta",
+		"example.com/stability.Min -> example.com/stability.Beta",
+		"example.com/stability.Max -> example.com/stability.Beta",
+		"example.com/stability.First -> example.com/stability.Split",
+		"example.com/stability.Second -> example.com/stability.Split",
+		// the locals, the parameters and the fields of Parse do not fall back to the package clause
+		"example.com/stability/beta.Level -> example.com/stability/beta.level",
+		"example.com/stability/beta.Parse -> example.com/stability/beta.Options",
+	}, violations)
+}
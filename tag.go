@@ -0,0 +1,58 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"regexp"
+	"strings"
+)
+
+var (
+	snakeCase = regexp.MustCompile(`^[a-z][a-z0-9]*(?:_[a-z0-9]+)*$`)
+	camelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+)
+
+// BeSnakeCase checks the name is in snake case, eg: first_name
+func BeSnakeCase(name, _ string) bool {
+	return snakeCase.MatchString(name)
+}
+
+// BeCamelCase checks the name is in lower camel case, eg: firstName
+func BeCamelCase(name, _ string) bool {
+	return camelCase.MatchString(name)
+}
+
+// TagsShould checks the names of the key in the tags of the struct fields match the pattern, the fields without the
+// key, ignored by "-" or without a name, eg: `json:",omitempty"`, are not checked, eg: TagsShould("json", BeSnakeCase)
+func (types Types) TagsShould(key string, pattern NamePattern, args ...string) error {
+	arg := lo.If(args == nil, "").ElseF(func() string {
+		return args[0]
+	})
+	result := types.fields(func(field internal.Field) (string, bool) {
+		value, ok := field.TagValue(key)
+		name, _, _ := strings.Cut(value, ",")
+		return fmt.Sprintf("%s:%q", key, name), ok && name != "" && name != "-" && !pattern(name, arg)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("tags %s break the rule: %w"), key, result.sorted())).Else(nil)
+}
+
+// ShouldHaveTag checks the exported fields of the structs have the key in their tags, the embedded fields are not
+// checked, eg: ShouldHaveTag("json") for the DTOs
+func (types Types) ShouldHaveTag(key string) error {
+	result := types.fields(func(field internal.Field) (string, bool) {
+		_, ok := field.TagValue(key)
+		return field.Type(), field.Exported() && !field.Embedded() && !ok
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("fields have no tag %s: %w"), key, result.sorted())).Else(nil)
+}
+
+// ShouldNotHaveTag checks no field of the structs has the key in its tag, eg: ShouldNotHaveTag("json") for the domain
+// entities
+func (types Types) ShouldNotHaveTag(key string) error {
+	result := types.fields(func(field internal.Field) (string, bool) {
+		_, ok := field.TagValue(key)
+		return field.Tag(), ok
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("fields have tag %s: %w"), key, result.sorted())).Else(nil)
+}
@@ -0,0 +1,33 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNamePattern_Case(t *testing.T) {
+	assert.True(t, BeSnakeCase("last_name", ""))
+	assert.True(t, BeSnakeCase("id", ""))
+	assert.False(t, BeSnakeCase("lastName", ""))
+	assert.False(t, BeSnakeCase("last__name", ""))
+	assert.True(t, BeCamelCase("lastName", ""))
+	assert.False(t, BeCamelCase("last_name", ""))
+	assert.False(t, BeCamelCase("LastName", ""))
+}
+
+func TestTypes_Tags(t *testing.T) {
+	arch, err := Load("testdata/tags")
+	assert.NoError(t, err)
+	dto, _ := arch.Packages("dto")
+	entity, _ := arch.Packages("entity")
+	err = dto.Structs().TagsShould("json", BeSnakeCase)
+	assert.EqualError(t, err, `tags json break the rule: [example.com/tags/dto.UserDTO.FirstName json:"firstName"]`)
+	err = dto.Structs().TagsShould("json", BeCamelCase)
+	assert.EqualError(t, err, `tags json break the rule: [example.com/tags/dto.UserDTO.LastName json:"last_name"]`)
+	assert.NoError(t, dto.Structs().TagsShould("yaml", BeSnakeCase))
+	err = dto.Structs().ShouldHaveTag("json")
+	assert.EqualError(t, err, "fields have no tag json: [example.com/tags/dto.UserDTO.Email string]")
+	assert.NoError(t, entity.Structs().ShouldNotHaveTag("yaml"))
+	err = entity.Structs().ShouldNotHaveTag("json")
+	assert.EqualError(t, err, "fields have tag json: [example.com/tags/entity.User.Name json:\"name\"]")
+}
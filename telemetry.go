@@ -0,0 +1,25 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"regexp"
+)
+
+// ExportedServiceMethodsShouldStartSpans checks every exported method of the service types calls the function matching
+// the span function pattern, functions are named as CallersOf, eg: go.opentelemetry.io/otel/trace.Tracer.Start$
+func ExportedServiceMethodsShouldStartSpans(services Types, spanFuncPattern string) error {
+	reg, err := regexp.Compile(spanFuncPattern)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(services.Methods(), func(method internal.Function, _ int) (Violation, bool) {
+		return violationAt(method.Position(), method.FullName()), method.Exported() && method.Decl() != nil &&
+			!ignoredFunction("ExportedServiceMethodsShouldStartSpans", method) &&
+			lo.NoneBy(method.CallSites(), func(site internal.CallSite) bool {
+				return reg.MatchString(funcName(site.Callee().Raw()))
+			})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("exported methods do not start spans %s: %w"), spanFuncPattern, Findings(result).sorted())).Else(nil)
+}
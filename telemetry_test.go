@@ -0,0 +1,20 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExportedServiceMethodsShouldStartSpans(t *testing.T) {
+	arch, err := Load("testdata/telemetry")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("telemetry")
+	assert.NoError(t, err)
+	services := pkgs.Types()
+	err = ExportedServiceMethodsShouldStartSpans(services, `Tracer\.Start$`)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"(example.com/telemetry.OrderService).Cancel"}, violations)
+	assert.Error(t, ExportedServiceMethodsShouldStartSpans(services, "[a"))
+	assert.NoError(t, ExportedServiceMethodsShouldStartSpans(services.Skip("example.com/telemetry.OrderService"), `Tracer\.Start$`))
+}
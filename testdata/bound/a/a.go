@@ -0,0 +1,9 @@
+package a
+
+import "example.com/bound/b"
+
+var name string
+
+func init() {
+	name = b.Name()
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"example.com/bound/flags"
+	"os"
+	"syscall"
+)
+
+// Store is the contract of the stores
+type Store interface {
+	Get(key string) string
+}
+
+// MemStore implements Store
+type MemStore struct{}
+
+func (MemStore) Get(key string) string {
+	return key
+}
+
+// FileStore is meant to be a Store but misses Get
+type FileStore struct{}
+
+func (FileStore) Read(key string) string {
+	return key
+}
+
+// Parse panics on the empty input
+func Parse(s string) string {
+	if s == "" {
+		panic("empty input")
+	}
+	return s
+}
+
+// Must panics on the empty input as well
+//
+//archunit:ignore ExportedFunctionsShouldNotPanic
+func Must(s string) string {
+	if s == "" {
+		panic("empty input")
+	}
+	return s
+}
+
+// Tested is referred by the test
+func Tested() bool {
+	return flags.Enabled("tested")
+}
+
+// Beta is not stable yet
+//
+//archunit:stability=experimental
+type Beta struct{}
+
+// Stable exposes the experimental type
+//
+//archunit:stability=stable
+func Stable() Beta {
+	return Beta{}
+}
+
+// Publish publishes an empty message to the topic
+func Publish(topic string) {}
+
+// Notify publishes the created orders
+func Notify() {
+	Publish("orders.created")
+}
+
+// Open opens the file and leaks it
+func Open(name string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	return syscall.Getpid(), f.Sync()
+}
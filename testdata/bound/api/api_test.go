@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/kcmvp/archunit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTested(t *testing.T) {
+	assert.False(t, Tested())
+	_, err := archunit.Layer("api/...")
+	assert.NoError(t, err)
+}
+
+func TestColor(t *testing.T) {
+	// the field Name does not test the function Name
+	tests := []struct{ Name string }{{Name: "red"}}
+	for _, test := range tests {
+		assert.NotEmpty(t, test.Name)
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+// Color is an enum
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+// Name misses Blue
+func Name(c Color) string {
+	switch c {
+	case Red:
+		return "red"
+	case Green:
+		return "green"
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package api_test
+
+import (
+	"bytes"
+	"testing"
+
+	"example.com/bound/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemStore(t *testing.T) {
+	assert.Equal(t, "key", api.MemStore{}.Get("key"))
+	// the method Read of bytes.Buffer does not test the method Read of FileStore
+	n, _ := bytes.NewBufferString("key").Read(make([]byte, 3))
+	assert.Equal(t, 3, n)
+}
@@ -0,0 +1,12 @@
+package b
+
+var name string
+
+func init() {
+	name = "b"
+}
+
+// Name returns the name of the package
+func Name() string {
+	return name
+}
@@ -0,0 +1,6 @@
+package flags
+
+// Enabled reports whether the flag is on
+func Enabled(flag string) bool {
+	return flag != ""
+}
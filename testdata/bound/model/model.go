@@ -0,0 +1,6 @@
+package model
+
+// User is the entity created by the services only
+type User struct {
+	Name string
+}
@@ -0,0 +1,32 @@
+package service
+
+import "example.com/bound/model"
+
+// UserService depends on OrderService
+type UserService struct{}
+
+// OrderService depends on UserService
+type OrderService struct{}
+
+func NewUserService(orders *OrderService) *UserService {
+	return &UserService{}
+}
+
+func NewOrderService(users *UserService) (*OrderService, error) {
+	return &OrderService{}, nil
+}
+
+// Retry retries the call n times, range over integer requires go1.22
+func Retry(n int, call func() error) (err error) {
+	for range n {
+		if err = call(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Find constructs the user
+func Find(name string) model.User {
+	return model.User{Name: name}
+}
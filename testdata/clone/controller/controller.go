@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+)
+
+func Render(users []string) string {
+	var cleaned []string
+	for _, user := range users {
+		user = strings.TrimSpace(strings.ToLower(user))
+		if user != "" && !strings.HasPrefix(user, "#") {
+			cleaned = append(cleaned, user)
+		}
+	}
+	return fmt.Sprint(cleaned)
+}
@@ -0,0 +1,14 @@
+package service
+
+import "strings"
+
+func Normalize(names []string) []string {
+	var result []string
+	for _, name := range names {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name != "" && !strings.HasPrefix(name, "#") {
+			result = append(result, name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package closures
+
+import "context"
+
+var Handler = func(id string, ctx context.Context) error {
+	return nil
+}
+
+var parse = func(text string) (error, int) {
+	return nil, len(text)
+}
+
+type Server struct{}
+
+func (s *Server) Handle() func(string, context.Context) {
+	return func(string, context.Context) {}
+}
+
+func NewMiddleware() func(context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		inner := func() (error, bool) {
+			return nil, true
+		}
+		_, ok := inner()
+		_, n := parse("")
+		return n, check(ok)
+	}
+}
+
+func check(ok bool) error {
+	return nil
+}
+
+func helper() func(string, context.Context) {
+	return func(string, context.Context) {}
+}
+
+func Lookup(id string, ctx context.Context) (error, string) {
+	helper()
+	return nil, id
+}
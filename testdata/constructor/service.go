@@ -0,0 +1,25 @@
+package service
+
+type Config struct{}
+
+type UserService struct{}
+
+type OrderService struct{}
+
+type AuditService struct{}
+
+func NewConfig() Config {
+	return Config{}
+}
+
+func NewUserService(cfg Config, orders *OrderService) *UserService {
+	return &UserService{}
+}
+
+func NewOrderService(users *UserService) (*OrderService, error) {
+	return &OrderService{}, nil
+}
+
+func NewAuditService(cfg Config) AuditService {
+	return AuditService{}
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "example.com/cycles/service"
+
+type UserDTO struct {
+	Name string
+}
+
+func FromService() UserDTO {
+	return UserDTO{Name: service.Find().Name}
+}
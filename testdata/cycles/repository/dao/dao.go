@@ -0,0 +1,7 @@
+package dao
+
+import "example.com/cycles/service"
+
+func Name() string {
+	return service.Find().Name
+}
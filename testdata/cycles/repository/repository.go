@@ -0,0 +1,5 @@
+package repository
+
+func Load() string {
+	return "archunit"
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"example.com/cycles/model"
+	"example.com/cycles/repository"
+)
+
+func Find() model.User {
+	return model.User{Name: repository.Load()}
+}
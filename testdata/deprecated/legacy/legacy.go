@@ -0,0 +1,14 @@
+package legacy
+
+import (
+	"crypto/dsa"
+	"io/ioutil"
+)
+
+func Read(file string) ([]byte, error) {
+	return ioutil.ReadFile(file)
+}
+
+func Sizes() dsa.ParameterSizes {
+	return dsa.L1024N160
+}
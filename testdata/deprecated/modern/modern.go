@@ -0,0 +1,11 @@
+package modern
+
+import (
+	"log"
+	"os"
+)
+
+func Read(file string) ([]byte, error) {
+	log.Println("read", file)
+	return os.ReadFile(file)
+}
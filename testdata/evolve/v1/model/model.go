@@ -0,0 +1,7 @@
+package model
+
+type User struct {
+	Name string
+}
+
+type Role string
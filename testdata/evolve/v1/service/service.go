@@ -0,0 +1,7 @@
+package service
+
+import "example.com/evolve/model"
+
+func Find() model.User {
+	return model.User{Name: "archunit"}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "example.com/evolve/model"
+
+func Load(name string) model.User {
+	return model.NewUser(name)
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"example.com/evolve/model"
+	"example.com/evolve/repository"
+)
+
+func Find(name string) model.User {
+	return repository.Load(name)
+}
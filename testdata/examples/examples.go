@@ -0,0 +1,3 @@
+package examples
+
+func Known() {}
@@ -0,0 +1,9 @@
+package examples
+
+func ExampleKnown() {
+	Known()
+}
+
+func ExampleUnknown() {
+	Known()
+}
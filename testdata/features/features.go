@@ -0,0 +1,26 @@
+package features
+
+import (
+	"iter"
+	"slices"
+)
+
+type Set[T comparable] map[T]struct{}
+
+func Count(n int) int {
+	total := 0
+	for i := range n {
+		total += i
+	}
+	return min(total, 100)
+}
+
+func All(values []string) iter.Seq[string] {
+	return slices.Values(values)
+}
+
+func Print(values []string) {
+	for v := range All(values) {
+		println(v)
+	}
+}
@@ -0,0 +1,67 @@
+package worker
+
+import "log"
+
+func Recover() {
+	if r := recover(); r != nil {
+		log.Println(r)
+	}
+}
+
+func Go(f func()) {
+	go func() {
+		defer Recover()
+		f()
+	}()
+}
+
+func Recovered() {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Println(r)
+			}
+		}()
+		log.Println("work")
+	}()
+}
+
+func Helper() {
+	go func() {
+		defer Recover()
+		log.Println("work")
+	}()
+	Go(func() {
+		log.Println("work")
+	})
+}
+
+func Crash() {
+	go func() {
+		log.Println("work")
+	}()
+}
+
+func Unbounded(jobs []string) {
+	for _, job := range jobs {
+		Go(func() {
+			log.Println(job)
+		})
+		go func() {
+			defer Recover()
+			log.Println(job)
+		}()
+	}
+}
+
+func Bounded(jobs []string) {
+	sem := make(chan struct{}, 4)
+	for _, job := range jobs {
+		sem <- struct{}{}
+		go func() {
+			defer Recover()
+			defer func() { <-sem }()
+			log.Println(job)
+		}()
+	}
+}
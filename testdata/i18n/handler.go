@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"net/http"
+
+	"example.com/i18n/messages"
+)
+
+func Catalog(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, messages.NotFound, http.StatusNotFound)
+	http.Error(w, messages.T("forbidden"), http.StatusForbidden)
+}
+
+func Literal(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "bad request: "+r.URL.Path, http.StatusBadRequest)
+}
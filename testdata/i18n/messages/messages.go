@@ -0,0 +1,7 @@
+package messages
+
+const NotFound = "not found"
+
+func T(key string) string {
+	return key
+}
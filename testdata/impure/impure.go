@@ -0,0 +1,44 @@
+package impure
+
+import (
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+)
+
+var home = os.Getenv("HOME")
+
+var upper = strings.ToUpper("pure")
+
+var handler = func() string {
+	return os.Getenv("LAZY")
+}
+
+var client = http.DefaultClient
+
+var lower, user = strings.ToLower("PURE"), os.Getenv("USER")
+
+var wd, wdErr = os.Getwd()
+
+var mux = http.NewServeMux()
+
+var status = http.StatusText(http.StatusOK)
+
+var addr = netip.MustParseAddr("127.0.0.1")
+
+var site, siteErr = url.Parse("https://example.com")
+
+var resp, respErr = http.Get("https://example.com")
+
+func init() {
+	go func() {}()
+	if _, err := os.ReadFile("config.yaml"); err != nil {
+		upper = ""
+	}
+}
+
+func Home() string {
+	return os.Getenv("HOME")
+}
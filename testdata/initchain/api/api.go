@@ -0,0 +1,7 @@
+package api
+
+import "fixture/db"
+
+func Source() string {
+	return db.DSN
+}
@@ -0,0 +1,9 @@
+package app
+
+import "fixture/api"
+
+var source string
+
+func init() {
+	source = api.Source()
+}
@@ -0,0 +1,7 @@
+package config
+
+var Settings map[string]string
+
+func init() {
+	Settings = map[string]string{"dsn": "memory"}
+}
@@ -0,0 +1,9 @@
+package db
+
+import "fixture/config"
+
+var DSN string
+
+func init() {
+	DSN = config.Settings["dsn"]
+}
@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type ctxKey string
+
+const (
+	userKey   ctxKey = "user"
+	tenantKey        = "tenant"
+	Trace            = "X-Trace-Id"
+)
+
+var cache sync.Map
+
+func Typed(ctx context.Context, r *http.Request) context.Context {
+	cache.Store(userKey, 1)
+	r.Header.Set(Trace, "1")
+	return context.WithValue(ctx, userKey, "admin")
+}
+
+func Raw(ctx context.Context, r *http.Request) context.Context {
+	cache.Load("user")
+	r.Header.Get("X-Trace-Id")
+	ctx.Value(tenantKey)
+	r.Header.Del("X-" + Trace)
+	key := "tenant"
+	cache.Delete(key)
+	return context.WithValue(ctx, "user", "admin")
+}
@@ -0,0 +1,56 @@
+package router
+
+import "net/http"
+
+type Router struct{}
+
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {}
+
+func Recovery(next http.Handler) http.Handler {
+	return next
+}
+
+func Auth(next http.Handler) http.Handler {
+	return next
+}
+
+func Logging() func(http.Handler) http.Handler {
+	return Auth
+}
+
+func Ordered() *Router {
+	r := &Router{}
+	r.Use(Recovery)
+	r.Use(Logging(), Auth)
+	return r
+}
+
+func Reversed() *Router {
+	r := &Router{}
+	r.Use(Auth, Recovery)
+	return r
+}
+
+func Missing() *Router {
+	r := &Router{}
+	r.Use(Recovery)
+	return r
+}
+
+type Engine struct{}
+
+func (e *Engine) Use(middlewares ...func(http.Handler) http.Handler) *Engine {
+	return e
+}
+
+func Chained() *Engine {
+	e := &Engine{}
+	e.Use(Recovery).Use(Logging()).Use(Auth)
+	return e
+}
+
+func Late() *Router {
+	r := &Router{}
+	r.Use(Logging(), Recovery, Auth)
+	return r
+}
@@ -0,0 +1,23 @@
+package impl
+
+type Echo struct{}
+
+func (Echo) Name() string {
+	return "echo"
+}
+
+type Upper struct{}
+
+func (*Upper) Name() string {
+	return "upper"
+}
+
+func NewUpper() *Upper {
+	return &Upper{}
+}
+
+type Lower struct{}
+
+func (Lower) Name() string {
+	return "lower"
+}
@@ -0,0 +1,11 @@
+package plugin
+
+type Plugin interface {
+	Name() string
+}
+
+var plugins = map[string]Plugin{}
+
+func Register(p Plugin) {
+	plugins[p.Name()] = p
+}
@@ -0,0 +1,11 @@
+package plugins
+
+import (
+	"example.com/plugins/impl"
+	"example.com/plugins/plugin"
+)
+
+func init() {
+	plugin.Register(impl.Echo{})
+	plugin.Register(impl.NewUpper())
+}
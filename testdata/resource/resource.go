@@ -0,0 +1,22 @@
+package resource
+
+import "os"
+
+// Log keeps the opened file in the field, it is closed by Close
+type Log struct {
+	f *os.File
+}
+
+func (l *Log) Open(name string) (err error) {
+	l.f, err = os.Open(name)
+	return err
+}
+
+func (l *Log) Close() error {
+	return l.f.Close()
+}
+
+func Leak(name string) error {
+	_, err := os.Open(name)
+	return err
+}
@@ -0,0 +1,19 @@
+//archunit:stability=experimental
+package beta
+
+var level = 1
+
+type Options struct {
+	Depth int
+}
+
+//archunit:stability=stable
+func Parse(s string, options Options) int {
+	n := len(s)
+	return n + options.Depth
+}
+
+//archunit:stability=stable
+func Level() int {
+	return level
+}
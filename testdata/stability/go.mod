@@ -0,0 +1,3 @@
+module example.com/stability
+
+go 1.22
@@ -0,0 +1,18 @@
+package stability
+
+//archunit:stability=experimental
+type Beta int
+
+//archunit:stability=experimental
+func Split() (int, int) {
+	return 1, 2
+}
+
+//archunit:stability=stable
+var Timeout, Retries = 30, Beta(3)
+
+//archunit:stability=stable
+var Min, Max Beta
+
+//archunit:stability=stable
+var First, Second = Split()
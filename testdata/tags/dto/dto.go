@@ -0,0 +1,15 @@
+package dto
+
+type UserDTO struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"last_name,omitempty"`
+	Password  string `json:"-"`
+	Email     string
+	internal  string
+}
+
+type PageDTO struct {
+	UserDTO `json:",inline"`
+	Total   int `json:"total" yaml:"total"`
+}
@@ -0,0 +1,6 @@
+package entity
+
+type User struct {
+	ID   string
+	Name string `json:"name"`
+}
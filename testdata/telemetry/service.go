@@ -0,0 +1,28 @@
+package service
+
+import "context"
+
+type Span interface {
+	End()
+}
+
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type OrderService struct {
+	tracer Tracer
+}
+
+func (s OrderService) Place(ctx context.Context) {
+	ctx, span := s.tracer.Start(ctx, "place")
+	defer span.End()
+	s.validate(ctx)
+}
+
+func (s OrderService) Cancel(ctx context.Context) {
+	s.validate(ctx)
+}
+
+func (s OrderService) validate(ctx context.Context) {
+}
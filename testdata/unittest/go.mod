@@ -0,0 +1,3 @@
+module example.com/unittest
+
+go 1.22
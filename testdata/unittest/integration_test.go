@@ -0,0 +1,12 @@
+//go:build integration
+
+package unittest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRemote(t *testing.T) {
+	_, _ = http.Get("https://example.com")
+}
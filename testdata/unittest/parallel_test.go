@@ -0,0 +1,14 @@
+package unittest
+
+import "testing"
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+	t.Run("sub", func(t *testing.T) {
+		t.Parallel()
+	})
+}
+
+func helper(t *testing.T) {
+	t.Helper()
+}
@@ -0,0 +1,5 @@
+package unittest
+
+func Sum(a, b int) int {
+	return a + b
+}
@@ -0,0 +1,18 @@
+package unittest
+
+import (
+	"net/http"
+	"os"
+	run "os/exec"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	if Sum(1, 2) != 3 {
+		t.Fail()
+	}
+	_, _ = os.ReadFile("testdata/input.txt")
+	_, _ = os.ReadFile("/etc/hosts")
+	_, _ = http.Get("https://example.com")
+	_ = run.Command("ls")
+}
@@ -0,0 +1,160 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// untestable is the functions touching the network or the file system keyed by the package path, the file system
+// functions are only reported when the first argument is an absolute path literal
+var untestable = map[string][]string{
+	"net/http": {"Get", "Head", "Post", "PostForm", "DefaultClient"},
+	"net":      {"Dial", "DialTimeout", "DialTCP", "DialUDP", "Listen", "ListenTCP", "ListenUDP", "ListenPacket"},
+	"os/exec":  {"Command", "CommandContext"},
+	"os": {"Open", "OpenFile", "Create", "ReadFile", "WriteFile", "ReadDir", "Remove", "RemoveAll", "Mkdir",
+		"MkdirAll", "Stat", "Lstat", "Chmod", "Rename"},
+}
+
+// UnitTestsShouldNotTouchNetworkOrFilesystem checks the _test.go files of the packages of the selection do not call
+// the net/http clients, dial or listen on the network, run commands with os/exec or access files by absolute paths.
+// test files with the integration build tag, eg: //go:build integration, are integration tests and not checked
+func UnitTestsShouldNotTouchNetworkOrFilesystem(pkgs ArchPackage) error {
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.TestFiles(), func(file *ast.File, _ int) {
+			if integration(file) {
+				return
+			}
+			ast.Inspect(file, func(node ast.Node) bool {
+				sel, ok := node.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				x, ok := sel.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				for pkgPath, names := range untestable {
+					if importName(file, pkgPath) != x.Name || !lo.Contains(names, sel.Sel.Name) {
+						continue
+					}
+					if pkgPath != "os" || absolutePath(file, sel) {
+						position := pkg.Raw().Fset.Position(sel.Pos())
+						result = append(result, violationAt(position, fmt.Sprintf("%s %s.%s", position, pkgPath, sel.Sel.Name)))
+					}
+				}
+				return true
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("unit tests touch network or file system: %w"), result.sorted())).Else(nil)
+}
+
+// integration reports whether the file is constrained by the integration build tag
+func integration(file *ast.File) bool {
+	return lo.SomeBy(file.Comments, func(group *ast.CommentGroup) bool {
+		return group.Pos() < file.Package && lo.SomeBy(group.List, func(comment *ast.Comment) bool {
+			return strings.HasPrefix(comment.Text, "//go:build") && lo.Contains(strings.FieldsFunc(comment.Text[len("//go:build"):], func(r rune) bool {
+				return !(r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+			}), "integration")
+		})
+	})
+}
+
+// importName returns the name of the imported package in the file, returns "" when the package is not imported
+func importName(file *ast.File, pkgPath string) string {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == pkgPath {
+			if spec.Name != nil {
+				return spec.Name.Name
+			}
+			return path.Base(pkgPath)
+		}
+	}
+	return ""
+}
+
+// absolutePath reports whether the selector is called with an absolute path literal as the first argument
+func absolutePath(file *ast.File, sel *ast.SelectorExpr) bool {
+	absolute := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok && call.Fun == sel && len(call.Args) > 0 {
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				value, _ := strconv.Unquote(lit.Value)
+				absolute = strings.HasPrefix(value, "/") || (len(value) > 2 && value[1] == ':' && (value[2] == '\\' || value[2] == '/'))
+			}
+		}
+		return !absolute
+	})
+	return absolute
+}
+
+// TestsShouldCallParallel checks the test functions in the _test.go files of the packages of the selection call
+// t.Parallel() in their bodies, except for the test functions named in the exceptions
+func TestsShouldCallParallel(pkgs ArchPackage, exceptions ...string) error {
+	var result Findings
+	lo.ForEach(pkgs, func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.TestFiles(), func(file *ast.File, _ int) {
+			lo.ForEach(file.Decls, func(decl ast.Decl, _ int) {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Recv != nil || fd.Body == nil || !strings.HasPrefix(fd.Name.Name, "Test") ||
+					lo.Contains(exceptions, fd.Name.Name) || fd.Type.Params.NumFields() != 1 || len(fd.Type.Params.List[0].Names) != 1 {
+					return
+				}
+				t := fd.Type.Params.List[0].Names[0].Name
+				if types.ExprString(fd.Type.Params.List[0].Type) != fmt.Sprintf("*%s.T", importName(file, "testing")) {
+					return
+				}
+				if !lo.SomeBy(fd.Body.List, func(stmt ast.Stmt) bool {
+					expr, ok := stmt.(*ast.ExprStmt)
+					return ok && types.ExprString(expr.X) == fmt.Sprintf("%s.Parallel()", t)
+				}) {
+					position := pkg.Raw().Fset.Position(fd.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf("%s %s", position, fd.Name.Name)))
+				}
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("tests do not call Parallel: %w"), result.sorted())).Else(nil)
+}
+
+// AssertionLibraries is the known assertion and mocking libraries, append the libraries to be governed to it
+var AssertionLibraries = []string{"github.com/stretchr/testify", "github.com/onsi/gomega", "github.com/onsi/ginkgo",
+	"gotest.tools", "github.com/matryer/is", "github.com/frankban/quicktest", "github.com/smartystreets/goconvey",
+	"github.com/alecthomas/assert", "github.com/golang/mock", "go.uber.org/mock", "github.com/vektra/mockery",
+	"github.com/google/go-cmp"}
+
+// TestsShouldUseAssertionLibrary checks the _test.go files of the project only import the allowed ones of the
+// AssertionLibraries, so the test dependencies are consistent. libraries are matched by the prefixes of the import paths
+func TestsShouldUseAssertionLibrary(allowed ...string) error {
+	return Project().TestsShouldUseAssertionLibrary(allowed...)
+}
+
+// TestsShouldUseAssertionLibrary checks the _test.go files of the architecture, see the top level one
+func (arch Architecture) TestsShouldUseAssertionLibrary(allowed ...string) error {
+	matches := func(prefixes []string, importPath string) bool {
+		return lo.SomeBy(prefixes, func(prefix string) bool {
+			return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+		})
+	}
+	var result Findings
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.TestFiles(), func(file *ast.File, _ int) {
+			lo.ForEach(file.Imports, func(spec *ast.ImportSpec, _ int) {
+				importPath, _ := strconv.Unquote(spec.Path.Value)
+				if matches(AssertionLibraries, importPath) && !matches(allowed, importPath) {
+					position := pkg.Raw().Fset.Position(spec.Pos())
+					result = append(result, violationAt(position, fmt.Sprintf("%s %s", position, importPath)))
+				}
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("tests import assertion libraries out of %v: %w"), allowed, result.sorted())).Else(nil)
+}
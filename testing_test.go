@@ -0,0 +1,57 @@
+package archunit
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestUnitTestsShouldNotTouchNetworkOrFilesystem(t *testing.T) {
+	arch, err := Load("testdata/unittest")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("unittest")
+	assert.NoError(t, err)
+	err = UnitTestsShouldNotTouchNetworkOrFilesystem(pkgs)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	expected := []string{"unittest_test.go:15:9 os.ReadFile", "unittest_test.go:16:9 net/http.Get", "unittest_test.go:17:6 os/exec.Command"}
+	assert.Len(t, violations, len(expected))
+	for _, suffix := range expected {
+		assert.True(t, lo.SomeBy(violations, func(v string) bool {
+			return strings.HasSuffix(v, suffix)
+		}), suffix)
+	}
+	sample, _ := Packages("sample/...")
+	assert.NoError(t, UnitTestsShouldNotTouchNetworkOrFilesystem(sample))
+}
+
+func TestTestsShouldCallParallel(t *testing.T) {
+	arch, err := Load("testdata/unittest")
+	assert.NoError(t, err)
+	pkgs, err := arch.Packages("unittest")
+	assert.NoError(t, err)
+	err = TestsShouldCallParallel(pkgs)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.ElementsMatch(t, []string{"TestRemote", "TestSum"}, lo.Map(violations, func(v string, _ int) string {
+		return v[strings.LastIndex(v, " ")+1:]
+	}))
+	err = TestsShouldCallParallel(pkgs, "TestRemote")
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "unittest_test.go:10:1 TestSum"))
+	assert.NoError(t, TestsShouldCallParallel(pkgs, "TestRemote", "TestSum"))
+}
+
+func TestTestsShouldUseAssertionLibrary(t *testing.T) {
+	assert.NoError(t, TestsShouldUseAssertionLibrary("github.com/stretchr/testify"))
+	err := TestsShouldUseAssertionLibrary("github.com/onsi/gomega")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.NotEmpty(t, violations)
+	assert.True(t, lo.EveryBy(violations, func(v string) bool {
+		return strings.HasSuffix(v, " github.com/stretchr/testify/assert")
+	}))
+	assert.Error(t, TestsShouldUseAssertionLibrary("github.com/stretchr/testify/require"))
+}
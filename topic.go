@@ -0,0 +1,119 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/kcmvp/archunit/internal"
+	"github.com/samber/lo"
+	"go/constant"
+	"regexp"
+)
+
+// Extractor discovers the non-code artifacts, eg: message topics, from the call sites of the project.
+// it returns the artifact and true when the call site carries one
+type Extractor interface {
+	Extract(site CallSite) (string, bool)
+}
+
+// ExtractorFunc is an adapter to use ordinary functions as Extractor
+type ExtractorFunc func(site CallSite) (string, bool)
+
+func (f ExtractorFunc) Extract(site CallSite) (string, bool) {
+	return f(site)
+}
+
+// StringArg returns the extractor of the constant string argument at the index of the calls of the functions,
+// functions are named as CallersOf, eg: StringArg(0, "github.com/nats-io/nats.go.Conn.Publish")
+func StringArg(index int, functions ...string) Extractor {
+	return ExtractorFunc(func(site CallSite) (string, bool) {
+		args := site.Expr().Args
+		if index >= len(args) || !funcMatches(site.Callee().Raw(), functions...) {
+			return "", false
+		}
+		value := site.Package().Raw().TypesInfo.Types[args[index]].Value
+		if value == nil || value.Kind() != constant.String {
+			return "", false
+		}
+		return constant.StringVal(value), true
+	})
+}
+
+// Topic is a message topic published at the call site
+type Topic struct {
+	Name string
+	CallSite
+}
+
+// Topics is the topics discovered in the project
+type Topics []Topic
+
+// TopicsOf returns the topics discovered by the extractors in the project, a call site is taken by the first extractor
+// that recognizes it
+func TopicsOf(extractors ...Extractor) Topics {
+	return Project().TopicsOf(extractors...)
+}
+
+// TopicsOf returns the topics discovered by the extractors in the architecture, see the top level one
+func (arch Architecture) TopicsOf(extractors ...Extractor) Topics {
+	var topics Topics
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			for _, extractor := range extractors {
+				if name, ok := extractor.Extract(site); ok {
+					topics = append(topics, Topic{Name: name, CallSite: site})
+					break
+				}
+			}
+		})
+	})
+	return topics
+}
+
+// TopicsShouldMatch checks the names of the topics discovered by the extractors in the project match the regular
+// expression, eg: TopicsShouldMatch(`^orders\.[a-z]+$`, StringArg(0, "github.com/nats-io/nats.go.Conn.Publish"))
+func TopicsShouldMatch(pattern string, extractors ...Extractor) error {
+	return Project().TopicsShouldMatch(pattern, extractors...)
+}
+
+// TopicsShouldMatch checks the names of the topics discovered by the extractors in the architecture match the regular
+// expression
+func (arch Architecture) TopicsShouldMatch(pattern string, extractors ...Extractor) error {
+	return arch.TopicsOf(extractors...).ShouldMatch(pattern)
+}
+
+// Names returns the distinct names of the topics
+func (topics Topics) Names() []string {
+	return lo.Uniq(lo.Map(topics, func(topic Topic, _ int) string {
+		return topic.Name
+	}))
+}
+
+// ShouldMatch checks the names of the topics match the regular expression, eg: ^orders\.[a-z]+$
+func (topics Topics) ShouldMatch(pattern string) error {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(topics, func(topic Topic, _ int) (Violation, bool) {
+		return violationAt(topic.Position(), fmt.Sprintf("%s %s", callSite(topic.CallSite), topic.Name)), !reg.MatchString(topic.Name)
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("topics do not match %s: %w"), pattern, Findings(result).sorted())).Else(nil)
+}
+
+// OnlyPackagesMayPublishTo checks the topics matching the regular expression are only published from the packages of the paths
+func (topics Topics) OnlyPackagesMayPublishTo(topicPattern string, paths ...string) error {
+	reg, err := regexp.Compile(topicPattern)
+	if err != nil {
+		return err
+	}
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	result := lo.FilterMap(topics, func(topic Topic, _ int) (Violation, bool) {
+		return violationAt(topic.Position(), fmt.Sprintf("%s %s", callSite(topic.CallSite), topic.Name)), reg.MatchString(topic.Name) &&
+			lo.NoneBy(patterns, func(pattern *regexp.Regexp) bool {
+				return pattern.MatchString(topic.Package().ID())
+			})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("topics %s are published out of %v: %w"), topicPattern, paths, Findings(result).sorted())).Else(nil)
+}
@@ -0,0 +1,40 @@
+package archunit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestTopicsOf(t *testing.T) {
+	topics := TopicsOf(StringArg(0, "internal/sample/flags.Enabled"))
+	assert.ElementsMatch(t, []string{"audit", "login"}, topics.Names())
+	assert.NoError(t, topics.ShouldMatch("^[a-z]+$"))
+	err := topics.ShouldMatch("^log")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "github.com/kcmvp/archunit/internal/sample/service.AuditCall -> github.com/kcmvp/archunit/internal/sample/flags.Enabled audit"))
+	assert.Error(t, topics.ShouldMatch("[a"))
+	assert.NoError(t, topics.OnlyPackagesMayPublishTo("audit", "sample/service"))
+	err = topics.OnlyPackagesMayPublishTo(".*", "sample/service")
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "login_controller.go")
+	assert.Error(t, topics.OnlyPackagesMayPublishTo("[a"))
+	assert.Error(t, topics.OnlyPackagesMayPublishTo(".*", "sample/[a"))
+	custom := TopicsOf(ExtractorFunc(func(site CallSite) (string, bool) {
+		return site.Callee().Name(), site.Callee().Name() == "Enabled"
+	}))
+	assert.Equal(t, []string{"Enabled"}, custom.Names())
+}
+
+func TestTopicsShouldMatch(t *testing.T) {
+	assert.NoError(t, TopicsShouldMatch("^[a-z]+$", StringArg(0, "internal/sample/flags.Enabled")))
+	err := TopicsShouldMatch("^log", StringArg(0, "internal/sample/flags.Enabled"))
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasSuffix(violations[0], "flags.Enabled audit"))
+	assert.Error(t, TopicsShouldMatch("[a"))
+}
@@ -0,0 +1,110 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProjectTreeShouldBePortable checks the project tree of current module is portable, see Architecture.TreeShouldBePortable
+func ProjectTreeShouldBePortable() error {
+	return Project().TreeShouldBePortable()
+}
+
+// TreeShouldBePortable checks the project tree can be checked out on all the platforms: no symbolic link, which is
+// not supported by the windows checkouts by default, and no files or folders in a folder whose names differ only by
+// case, which collide on the case-insensitive file systems of macOS and windows
+func (arch Architecture) TreeShouldBePortable() error {
+	var result []string
+	folders := map[string][]string{}
+	err := arch.walk(func(path string, entry fs.DirEntry) {
+		if entry.Type()&fs.ModeSymlink != 0 {
+			result = append(result, fmt.Sprintf(localize("%s is a symbolic link"), path))
+		}
+		folders[filepath.Dir(path)] = append(folders[filepath.Dir(path)], entry.Name())
+	})
+	if err != nil {
+		return err
+	}
+	for folder, names := range folders {
+		collisions := lo.PickBy(lo.GroupBy(names, strings.ToLower), func(_ string, group []string) bool {
+			return len(group) > 1
+		})
+		for _, group := range collisions {
+			sort.Strings(group)
+			result = append(result, fmt.Sprintf(localize("%s has names differing only by case %v"), folder, group))
+		}
+	}
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("project tree is not portable: %w"), Violations(result))).Else(nil)
+}
+
+// PathsShouldBeShorterThan checks the paths of the project tree of current module, see
+// Architecture.PathsShouldBeShorterThan
+func PathsShouldBeShorterThan(n int) error {
+	return Project().PathsShouldBeShorterThan(n)
+}
+
+// PathsShouldMatch checks the names of the project tree of current module, see Architecture.PathsShouldMatch
+func PathsShouldMatch(pattern string) error {
+	return Project().PathsShouldMatch(pattern)
+}
+
+// PathsShouldBeShorterThan checks the paths of the files and the folders relative to the project root are shorter than
+// n characters, eg: 200 leaves room for the checkout directories within the MAX_PATH of windows
+func (arch Architecture) PathsShouldBeShorterThan(n int) error {
+	var result []string
+	err := arch.walk(func(path string, _ fs.DirEntry) {
+		if rel, _ := filepath.Rel(arch.RootDir(), path); len(rel) >= n {
+			result = append(result, fmt.Sprintf("%s (%d)", rel, len(rel)))
+		}
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("paths are not shorter than %d: %w"), n, Violations(result))).Else(nil)
+}
+
+// PathsShouldMatch checks the names of the files and the folders match the regular expression, eg: ^[a-zA-Z0-9._-]+$
+// for the url safe names
+func (arch Architecture) PathsShouldMatch(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	var result []string
+	err = arch.walk(func(path string, entry fs.DirEntry) {
+		if !re.MatchString(entry.Name()) {
+			rel, _ := filepath.Rel(arch.RootDir(), path)
+			result = append(result, rel)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("paths do not match %s: %w"), pattern, Violations(result))).Else(nil)
+}
+
+// walk visits the files and the folders of the project tree except the root and the hidden ones, eg: .git
+func (arch Architecture) walk(visit func(path string, entry fs.DirEntry)) error {
+	root := arch.RootDir()
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") {
+			return lo.If(entry.IsDir(), fs.SkipDir).Else(nil)
+		}
+		visit(path, entry)
+		return nil
+	})
+}
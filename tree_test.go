@@ -0,0 +1,59 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tempModule creates the go module of the files in a temporary directory
+func tempModule(t *testing.T, files ...string) string {
+	dir := t.TempDir()
+	files = append(files, "go.mod", "main.go")
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		content := map[string]string{"go.mod": "module example.com/tree\n\ngo 1.22\n", "main.go": "package main\n\nfunc main() {}\n"}[file]
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestArchitecture_TreeShouldBePortable(t *testing.T) {
+	dir := tempModule(t, "api/README.md", "api/readme.md", "Docs/a.txt", "docs/b.txt", ".git/HEAD", ".git/head")
+	assert.NoError(t, os.Symlink(filepath.Join(dir, "api"), filepath.Join(dir, "link")))
+	arch, err := Load(dir)
+	assert.NoError(t, err)
+	root := arch.RootDir()
+	var violations Violations
+	assert.ErrorAs(t, arch.TreeShouldBePortable(), &violations)
+	assert.Equal(t, Violations{
+		fmt.Sprintf("%s has names differing only by case [Docs docs]", root),
+		fmt.Sprintf("%s has names differing only by case [README.md readme.md]", filepath.Join(root, "api")),
+		fmt.Sprintf("%s is a symbolic link", filepath.Join(root, "link")),
+	}, violations)
+	arch, err = Load(tempModule(t, "api/README.md"))
+	assert.NoError(t, err)
+	assert.NoError(t, arch.TreeShouldBePortable())
+}
+
+func TestArchitecture_PathsShouldBeShorterThan(t *testing.T) {
+	arch, err := Load(tempModule(t, "internal/persistence/repository/user_repository.go", ".git/objects/abcdefghijklmnopqrstuvwxyz"))
+	assert.NoError(t, err)
+	err = arch.PathsShouldBeShorterThan(40)
+	assert.EqualError(t, err, "paths are not shorter than 40: [internal/persistence/repository/user_repository.go (50)]")
+	err = arch.PathsShouldBeShorterThan(31)
+	assert.EqualError(t, err, "paths are not shorter than 31: [internal/persistence/repository (31) internal/persistence/repository/user_repository.go (50)]")
+	assert.NoError(t, arch.PathsShouldBeShorterThan(51))
+}
+
+func TestArchitecture_PathsShouldMatch(t *testing.T) {
+	arch, err := Load(tempModule(t, "api/v1/user api.md", "api/v1/order#1.md", "api/v 2/order.md"))
+	assert.NoError(t, err)
+	err = arch.PathsShouldMatch(`^[a-zA-Z0-9._-]+$`)
+	assert.EqualError(t, err, "paths do not match ^[a-zA-Z0-9._-]+$: [api/v 2 api/v1/order#1.md api/v1/user api.md]")
+	assert.NoError(t, arch.PathsShouldMatch(`^[^\\/:*?"<>|]+$`))
+	assert.Error(t, arch.PathsShouldMatch(`[`))
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/kcmvp/archunit/internal"
 	"github.com/samber/lo"
 	lop "github.com/samber/lo/parallel"
+	"go/ast"
 	"go/types"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -33,7 +36,7 @@ func TypesWith(typeNames ...string) Types {
 func TypesEmbeddedWith(embeddedType string) (Types, error) {
 	eType, ok := internal.Arch().Type(embeddedType)
 	if !ok {
-		return Types{}, fmt.Errorf("can not find interface %s", embeddedType)
+		return Types{}, fmt.Errorf(localize("can not find interface %s"), embeddedType)
 	}
 	var typMap sync.Map
 	lo.ForEach(internal.Arch().Packages(), func(pkg *internal.Package, index int) {
@@ -83,6 +86,32 @@ func TypesImplement(interName string) (Types, error) {
 	return typs, nil
 }
 
+// MethodsImplementing returns the methods of the implementers of the interface which belong to the method set of the
+// interface, so the rules can be applied on the contract methods only. the methods promoted from the embedded types
+// are the methods of the embedded types, they are selected when the embedded types implement the interface
+func MethodsImplementing(iface string) (Functions, error) {
+	return Project().MethodsImplementing(iface)
+}
+
+// MethodsImplementing returns the contract methods of the implementers of the interface in the architecture, see the
+// top level one
+func (arch Architecture) MethodsImplementing(iface string) (Functions, error) {
+	inter, ok := arch.artifact.Type(iface)
+	if !ok || !inter.Interface() {
+		return Functions{}, fmt.Errorf(localize("can not find interface %s"), iface)
+	}
+	contract := lo.Map(inter.Methods(), func(f internal.Function, _ int) string {
+		return f.Name()
+	})
+	var methods Functions
+	lo.ForEach(Types{inter}.Implementers(), func(typ internal.Type, _ int) {
+		methods = append(methods, lo.Filter(typ.Methods(), func(f internal.Function, _ int) bool {
+			return lo.Contains(contract, f.Name())
+		})...)
+	})
+	return methods, nil
+}
+
 // Skip  filter out the specified types
 func (types Types) Skip(typNames ...string) Types {
 	return lo.Filter(types, func(typ internal.Type, _ int) bool {
@@ -94,9 +123,9 @@ func (types Types) Skip(typNames ...string) Types {
 func (types Types) EmbeddedWith(embedTyps ...string) (Types, error) {
 	var embedded []internal.Type
 	for _, typName := range embedTyps {
-		t, ok := internal.Arch().Type(typName)
+		t, ok := types.architecture().artifact.Type(typName)
 		if !ok {
-			return Types{}, fmt.Errorf("can not find type %s", typName)
+			return Types{}, fmt.Errorf(localize("can not find type %s"), typName)
 		}
 		embedded = append(embedded, t)
 	}
@@ -108,9 +137,9 @@ func (types Types) EmbeddedWith(embedTyps ...string) (Types, error) {
 func (types Types) Implement(interTyps ...string) (Types, error) {
 	var inters []internal.Type
 	for _, typName := range interTyps {
-		t, ok := internal.Arch().Type(typName)
+		t, ok := types.architecture().artifact.Type(typName)
 		if !ok {
-			return Types{}, fmt.Errorf("can not find type %s", typName)
+			return Types{}, fmt.Errorf(localize("can not find type %s"), typName)
 		}
 		inters = append(inters, t)
 	}
@@ -128,10 +157,170 @@ func (types Types) InPackages(paths ...string) Types {
 	})
 }
 
+// Implementers returns the types of the project implementing any interface of the selection, by value or by pointer
+func (types Types) Implementers() Types {
+	if len(types) == 0 {
+		return Types{}
+	}
+	return lo.Filter(ArchPackage(types.architecture().artifact.Packages()).Types(), func(typ internal.Type, _ int) bool {
+		return !typ.Interface() && lo.SomeBy(types, func(inter internal.Type) bool {
+			return inter.Interface() && implements(typ, inter)
+		})
+	})
+}
+
+// Embedders returns the types of the project embedding any type of the selection, eg: struct{ *T } or interface{ T }
+func (types Types) Embedders() Types {
+	if len(types) == 0 {
+		return Types{}
+	}
+	return lo.Filter(ArchPackage(types.architecture().artifact.Packages()).Types(), func(typ internal.Type, _ int) bool {
+		embedded := embeddedTypes(typ)
+		return lo.SomeBy(types, func(t internal.Type) bool {
+			return lo.Contains(embedded, t.Raw().Obj())
+		})
+	})
+}
+
+// Users returns the types of the project referring any type of the selection in their declarations, that is
+// the types of the fields and the signatures of the methods. the referred type itself is not a user of its own
+func (types Types) Users() Types {
+	if len(types) == 0 {
+		return Types{}
+	}
+	return lo.Filter(ArchPackage(types.architecture().artifact.Packages()).Types(), func(typ internal.Type, _ int) bool {
+		referred := typeReferences(typ)
+		return lo.SomeBy(types, func(t internal.Type) bool {
+			return t.Raw().Obj() != typ.Raw().Obj() && lo.Contains(referred, t.Raw().Obj())
+		})
+	})
+}
+
+// TypesIntendedToImplement checks the types of the project matching the matcher, eg: TypeNameMatches(HaveSuffix, "Repo"),
+// implement the interface by value or by pointer, so an implementation does not silently stop satisfying the contract
+// after a signature change. the interfaces matching the matcher are not checked
+func TypesIntendedToImplement(iface string, matcher Matcher[Type]) error {
+	return Project().TypesIntendedToImplement(iface, matcher)
+}
+
+// TypesIntendedToImplement checks the types of the architecture matching the matcher, see the top level one
+func (arch Architecture) TypesIntendedToImplement(iface string, matcher Matcher[Type]) error {
+	inter, ok := arch.artifact.Type(iface)
+	if !ok || !inter.Interface() {
+		return fmt.Errorf(localize("can not find interface %s"), iface)
+	}
+	result := lo.FilterMap(ArchPackage(arch.artifact.Packages()).Types().Matching(matcher), func(typ internal.Type, _ int) (Violation, bool) {
+		if typ.Interface() || implements(typ, inter) {
+			return Violation{}, false
+		}
+		method, wrongType := types.MissingMethod(types.NewPointer(typ.Raw()), inter.Raw().Underlying().(*types.Interface), true)
+		return violationAt(typ.Position(), fmt.Sprintf("%s %s %s", typ.Name(), lo.If(wrongType, "has wrong signature of").Else("misses"), method.Name())), true
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("types do not implement %s: %w"), iface, Findings(result).sorted())).Else(nil)
+}
+
+// ImplementationsShouldBeRegisteredIn checks the implementers of the interfaces of the selection, eg: the plugin
+// interfaces, are passed to the registry function somewhere in the module, eg: Register(NewX()) or Register(&X{}).
+// the registry function is matched by the suffix of its full name, eg: "plugin.Register". the arguments are recognized
+// by their static types, so the constructors should return the implementations rather than the interfaces
+func (types Types) ImplementationsShouldBeRegisteredIn(registryFunc string) error {
+	arch := types.architecture()
+	registered := registeredTypes(arch, registryFunc)
+	var result []string
+	for _, pkg := range arch.artifact.Packages() {
+		if !strings.HasPrefix(pkg.ID(), arch.Module()) {
+			continue
+		}
+		lo.ForEach(pkg.Types(), func(typ internal.Type, _ int) {
+			if !typ.Interface() && !registered[typ.Raw().Obj()] && lo.SomeBy(types, func(inter internal.Type) bool {
+				return inter.Interface() && implements(typ, inter)
+			}) && !arch.ignored("ImplementationsShouldBeRegisteredIn", typ.Raw().Obj()) {
+				result = append(result, typ.Name())
+			}
+		})
+	}
+	sort.Strings(result)
+	return lo.If(len(result) > 0, fmt.Errorf(localize("implementations are not registered in %s: %w"), registryFunc, Violations(result))).Else(nil)
+}
+
+// registeredTypes returns the types of the expressions in the arguments of the calls of the registry function
+func registeredTypes(arch Architecture, registryFunc string) map[*types.TypeName]bool {
+	registered := map[*types.TypeName]bool{}
+	lo.ForEach(arch.artifact.Packages(), func(pkg *internal.Package, _ int) {
+		lo.ForEach(pkg.CallSites(), func(site internal.CallSite, _ int) {
+			if !strings.HasSuffix(site.Callee().FullName(), registryFunc) {
+				return
+			}
+			lo.ForEach(site.Expr().Args, func(arg ast.Expr, _ int) {
+				ast.Inspect(arg, func(node ast.Node) bool {
+					if expr, ok := node.(ast.Expr); ok {
+						typ := pkg.Raw().TypesInfo.TypeOf(expr)
+						if ptr, ok := typ.(*types.Pointer); ok {
+							typ = ptr.Elem()
+						}
+						if name := typeNameOf(typ); name != nil {
+							registered[name] = true
+						}
+					}
+					return true
+				})
+			})
+		})
+	})
+	return registered
+}
+
+// architecture returns the architecture the types are loaded by, an empty architecture for an empty selection
+func (types Types) architecture() Architecture {
+	if len(types) > 0 {
+		return Architecture{artifact: types[0].Artifact()}
+	}
+	return Architecture{artifact: &internal.Artifact{}}
+}
+
+func implements(typ, inter internal.Type) bool {
+	iface := inter.Raw().Underlying().(*types.Interface)
+	return types.Implements(typ.Raw(), iface) || types.Implements(types.NewPointer(typ.Raw()), iface)
+}
+
+// embeddedTypes returns the types embedded in the struct or interface type
+func embeddedTypes(typ internal.Type) []*types.TypeName {
+	var embedded []*types.TypeName
+	switch underlying := typ.Raw().Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < underlying.NumFields(); i++ {
+			if field := underlying.Field(i); field.Embedded() {
+				fieldType := field.Type()
+				if ptr, ok := fieldType.(*types.Pointer); ok {
+					fieldType = ptr.Elem()
+				}
+				embedded = append(embedded, typeNameOf(fieldType))
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < underlying.NumEmbeddeds(); i++ {
+			embedded = append(embedded, typeNameOf(underlying.EmbeddedType(i)))
+		}
+	}
+	return lo.Compact(embedded)
+}
+
+// typeReferences returns the types referred by the underlying type and the method signatures of the type
+func typeReferences(typ internal.Type) []*types.TypeName {
+	seen := map[types.Type]bool{typ.Raw(): true}
+	referred := referredTypes(typ.Raw().Underlying(), seen)
+	for i := 0; i < typ.Raw().NumMethods(); i++ {
+		referred = append(referred, referredTypes(typ.Raw().Method(i).Type(), seen)...)
+	}
+	return lo.Uniq(lo.Map(referred, func(named *types.Named, _ int) *types.TypeName {
+		return named.Origin().Obj()
+	}))
+}
+
 // Methods return all the methods of the types
 func (types Types) Methods() Functions {
 	var functions Functions
-	lop.ForEach(types, func(typ internal.Type, _ int) {
+	lo.ForEach(types, func(typ internal.Type, _ int) {
 		functions = append(functions, typ.Methods()...)
 	})
 	return functions
@@ -142,13 +331,13 @@ func (types Types) FileSet() FileSet {
 }
 
 func (types Types) MethodShouldBeDefinedInOneFile() error {
-	for _, pkg := range internal.Arch().Packages() {
+	for _, pkg := range types.architecture().artifact.Packages() {
 		for _, typ := range pkg.Types() {
 			files := lo.Uniq(lo.Map(typ.Methods(), func(f internal.Function, _ int) string {
 				return f.GoFile()
 			}))
 			if len(files) > 1 {
-				return fmt.Errorf("methods of type %s are defined in files %v", typ.Name(), files)
+				return fmt.Errorf(localize("methods of type %s are defined in files %v"), typ.Name(), files)
 			}
 		}
 	}
@@ -160,7 +349,7 @@ func (types Types) ShouldBe(visible Visible) error {
 	if t, ok := lo.Find(types, func(typ internal.Type) bool {
 		return visible != lo.If(typ.Exported(), Public).Else(Private)
 	}); ok {
-		return fmt.Errorf("type %s is %s", t.Name(), lo.If(t.Exported(), "public").Else("private"))
+		return fmt.Errorf(localize("type %s is %s"), t.Name(), lo.If(t.Exported(), "public").Else("private"))
 	}
 	return nil
 }
@@ -169,7 +358,7 @@ func (types Types) ShouldBeInPackages(pkgs ...string) error {
 	if t, ok := lo.Find(types, func(typ internal.Type) bool {
 		return !lo.Contains(pkgs, typ.Package())
 	}); ok {
-		return fmt.Errorf("type is %s in %s", t.Name(), t.Package())
+		return fmt.Errorf(localize("type is %s in %s"), t.Name(), t.Package())
 	}
 	return nil
 }
@@ -180,7 +369,85 @@ func (types Types) NameShould(pattern NamePattern, args ...string) error {
 			return args[0]
 		}))
 	}); ok {
-		return fmt.Errorf("Type %s faild to pass naming checking", t.Name())
+		return fmt.Errorf(localize("Type %s faild to pass naming checking"), t.Name())
+	}
+	return nil
+}
+
+// ShouldOnlyBeConstructedIn checks the types are only instantiated with composite literals, eg: User{} or &User{},
+// in the specified layers, so entities are created by factories or repositories rather than ad hoc.
+// the package which declares the type is always allowed to construct it
+func (types Types) ShouldOnlyBeConstructedIn(layers ...ArchLayer) error {
+	var allowed []string
+	lo.ForEach(layers, func(layer ArchLayer, _ int) {
+		allowed = append(allowed, layer.packages()...)
+	})
+	var result Findings
+	if len(types) == 0 {
+		return nil
+	}
+	lo.ForEach(types.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		if lo.Contains(allowed, pkg.ID()) {
+			return
+		}
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			ast.Inspect(file, func(node ast.Node) bool {
+				if lit, ok := node.(*ast.CompositeLit); ok {
+					if typ, ok := lo.Find(types, func(typ internal.Type) bool {
+						return typ.Raw().Obj() == typeNameOf(pkg.Raw().TypesInfo.TypeOf(lit)) && typ.Package() != pkg.ID()
+					}); ok {
+						position := pkg.Raw().Fset.Position(lit.Pos())
+						result = append(result, violationAt(position, fmt.Sprintf(localize("%s at %s"), typ.Name(), position)))
+					}
+				}
+				return true
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("types are constructed out of %v: %w"), allowed, result.sorted())).Else(nil)
+}
+
+// typeNameOf returns the declaration of the named type, or nil for unnamed types
+func typeNameOf(typ types.Type) *types.TypeName {
+	if named, ok := typ.(*types.Named); ok {
+		return named.Origin().Obj()
 	}
 	return nil
 }
+
+// ShouldNotBeTypeAsserted checks the interfaces of the selection are not type asserted, neither x.(T) nor type switch,
+// out of the specified packages. downcasting an abstraction to its implementation usually signals a leaky abstraction
+func (types Types) ShouldNotBeTypeAsserted(paths ...string) error {
+	patterns, err := ScopePattern(paths...)
+	if err != nil {
+		return err
+	}
+	interfaces := lo.Filter(types, func(typ internal.Type, _ int) bool {
+		return typ.Interface()
+	})
+	var result Findings
+	if len(interfaces) == 0 {
+		return nil
+	}
+	lo.ForEach(types.architecture().artifact.Packages(), func(pkg *internal.Package, _ int) {
+		if lo.SomeBy(patterns, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(pkg.ID())
+		}) {
+			return
+		}
+		lo.ForEach(pkg.Raw().Syntax, func(file *ast.File, _ int) {
+			ast.Inspect(file, func(node ast.Node) bool {
+				if expr, ok := node.(*ast.TypeAssertExpr); ok {
+					if typ, ok := lo.Find(interfaces, func(typ internal.Type) bool {
+						return typ.Raw().Obj() == typeNameOf(pkg.Raw().TypesInfo.TypeOf(expr.X))
+					}); ok {
+						position := pkg.Raw().Fset.Position(expr.Pos())
+						result = append(result, violationAt(position, fmt.Sprintf(localize("%s at %s"), typ.Name(), position)))
+					}
+				}
+				return true
+			})
+		})
+	})
+	return lo.If(len(result) > 0, fmt.Errorf(localize("interfaces are type asserted out of %v: %w"), paths, result.sorted())).Else(nil)
+}
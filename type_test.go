@@ -4,6 +4,7 @@ import (
 	"github.com/kcmvp/archunit/internal"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -14,6 +15,12 @@ func TestAllTypes(t *testing.T) {
 	})
 	expected := []string{
 		"github.com/kcmvp/archunit/internal.Artifact",
+		"github.com/kcmvp/archunit/internal.Field",
+		"github.com/kcmvp/archunit/internal.Constant",
+		"github.com/kcmvp/archunit/internal.importerFunc",
+		"github.com/kcmvp/archunit/internal.IndexPackage",
+		"github.com/kcmvp/archunit/internal.Index",
+		"github.com/kcmvp/archunit/internal.CallSite",
 		"github.com/kcmvp/archunit/internal.Function",
 		"github.com/kcmvp/archunit/internal.Package",
 		"github.com/kcmvp/archunit/internal.Param",
@@ -24,9 +31,94 @@ func TestAllTypes(t *testing.T) {
 		"github.com/kcmvp/archunit/internal/sample/controller/module1.AppController",
 		"github.com/kcmvp/archunit/internal/sample/service/ext.Cross",
 		"github.com/kcmvp/archunit/internal/sample/model.User",
+		"github.com/kcmvp/archunit/internal/sample/flags.Client",
 		"github.com/kcmvp/archunit/internal/sample/vutil.ViewUtil",
 		"github.com/kcmvp/archunit.PackageFile",
 		"github.com/kcmvp/archunit.FileSet",
+		"github.com/kcmvp/archunit/diagnostics.collector",
+		"github.com/kcmvp/archunit.Findings",
+		"github.com/kcmvp/archunit.summaryOptions",
+		"github.com/kcmvp/archunit.TeamReporter",
+		"github.com/kcmvp/archunit.ownerEntry",
+		"github.com/kcmvp/archunit.Ownership",
+		"github.com/kcmvp/archunit.declaration",
+		"github.com/kcmvp/archunit.folderOverride",
+		"github.com/kcmvp/archunit.FolderContent",
+		"github.com/kcmvp/archunit.ExitCodes",
+		"github.com/kcmvp/archunit.Messages",
+		"github.com/kcmvp/archunit.MessageCatalog",
+		"github.com/kcmvp/archunit.ConstantSelection",
+		"github.com/kcmvp/archunit/analyzer.validation",
+		"github.com/kcmvp/archunit/analyzer.collector",
+		"github.com/kcmvp/archunit/analyzer.checker",
+		"github.com/kcmvp/archunit.ViolationContext",
+		"github.com/kcmvp/archunit/config.RuleConfig",
+		"github.com/kcmvp/archunit/config.Config",
+		"github.com/kcmvp/archunit/config.BestPractices",
+		"github.com/kcmvp/archunit.Matcher[T any]",
+		"github.com/kcmvp/archunit.umlDiagram",
+		"github.com/kcmvp/archunit.ArchDiff",
+		"github.com/kcmvp/archunit.Dependency",
+		"github.com/kcmvp/archunit.DependencyGraph",
+		"github.com/kcmvp/archunit.savedArtifact",
+		"github.com/kcmvp/archunit/junit.Reporter",
+		"github.com/kcmvp/archunit/junit.Failure",
+		"github.com/kcmvp/archunit/junit.TestCase",
+		"github.com/kcmvp/archunit/junit.TestSuite",
+		"github.com/kcmvp/archunit/junit.TestSuites",
+		"github.com/kcmvp/archunit.Violation",
+		"github.com/kcmvp/archunit.RuleProvider",
+		"github.com/kcmvp/archunit/sarif.Reporter",
+		"github.com/kcmvp/archunit/sarif.Region",
+		"github.com/kcmvp/archunit/sarif.ArtifactLocation",
+		"github.com/kcmvp/archunit/sarif.PhysicalLocation",
+		"github.com/kcmvp/archunit/sarif.Location",
+		"github.com/kcmvp/archunit/sarif.Result",
+		"github.com/kcmvp/archunit/sarif.Message",
+		"github.com/kcmvp/archunit/sarif.Rule",
+		"github.com/kcmvp/archunit/sarif.Driver",
+		"github.com/kcmvp/archunit/sarif.Tool",
+		"github.com/kcmvp/archunit/sarif.Run",
+		"github.com/kcmvp/archunit/sarif.Log",
+		"github.com/kcmvp/archunit.Reporter",
+		"github.com/kcmvp/archunit.RuleResult",
+		"github.com/kcmvp/archunit.httpStore",
+		"github.com/kcmvp/archunit.dirStore",
+		"github.com/kcmvp/archunit.CacheStore",
+		"github.com/kcmvp/archunit.cachedResult",
+		"github.com/kcmvp/archunit.CacheStats",
+		"github.com/kcmvp/archunit.ResultCache",
+		"github.com/kcmvp/archunit.layerDefinition",
+		"github.com/kcmvp/archunit.LayerConstraint",
+		"github.com/kcmvp/archunit/promote.ArchSuite",
+		"github.com/kcmvp/archunit.cloneToken",
+		"github.com/kcmvp/archunit.manifest",
+		"github.com/kcmvp/archunit.CustomObjects",
+		"github.com/kcmvp/archunit.CustomExtractor",
+		"github.com/kcmvp/archunit.CustomObject",
+		"github.com/kcmvp/archunit.MiddlewareChains",
+		"github.com/kcmvp/archunit.MiddlewareChain",
+		"github.com/kcmvp/archunit.Topics",
+		"github.com/kcmvp/archunit.Topic",
+		"github.com/kcmvp/archunit.ExtractorFunc",
+		"github.com/kcmvp/archunit.Extractor",
+		"github.com/kcmvp/archunit/diagnostics.Range",
+		"github.com/kcmvp/archunit/diagnostics.PublishDiagnosticsParams",
+		"github.com/kcmvp/archunit/diagnostics.Position",
+		"github.com/kcmvp/archunit/diagnostics.Diagnostic",
+		"github.com/kcmvp/archunit/diagnostics.LogMessageParams",
+		"github.com/kcmvp/archunit.RuleInfo",
+		"github.com/kcmvp/archunit.reportOptions",
+		"github.com/kcmvp/archunit.ReportOption",
+		"github.com/kcmvp/archunit.Violations",
+		"github.com/kcmvp/archunit.LayeredArchitecture",
+		"github.com/kcmvp/archunit.RuleCoverage",
+		"github.com/kcmvp/archunit.Rule",
+		"github.com/kcmvp/archunit.Architecture",
+		"github.com/kcmvp/archunit.EnumConstants",
+		"github.com/kcmvp/archunit.Callers",
+		"github.com/kcmvp/archunit.FeatureFlags",
+		"github.com/kcmvp/archunit.CoverProfile",
 		"github.com/kcmvp/archunit.Functions",
 		"github.com/kcmvp/archunit.ArchLayer",
 		"github.com/kcmvp/archunit.NamePattern",
@@ -124,7 +216,7 @@ func TestTypes_Skip(t *testing.T) {
 		{
 			name:      "skip_internal.Type",
 			typeNames: []string{"github.com/kcmvp/archunit/internal.Type"},
-			num:       33,
+			num:       124,
 		},
 		{
 			name: "skip_internal.Type_archunit.PackageFile",
@@ -132,7 +224,7 @@ func TestTypes_Skip(t *testing.T) {
 				"github.com/kcmvp/archunit/internal.Type",
 				"github.com/kcmvp/archunit.PackageFile",
 			},
-			num: 32,
+			num: 123,
 		},
 		{
 			name: "skip_internal.Type_archunit.File_service.Audit",
@@ -141,7 +233,7 @@ func TestTypes_Skip(t *testing.T) {
 				"github.com/kcmvp/archunit.PackageFile",
 				"github.com/kcmvp/archunit/internal/sample/service.Audit",
 			},
-			num: 31,
+			num: 122,
 		},
 	}
 	for _, test := range tests {
@@ -167,6 +259,12 @@ func TestTypes_InPackages(t *testing.T) {
 			pkgs: []string{"archunit/internal"},
 			typs: []string{
 				"github.com/kcmvp/archunit/internal.Artifact",
+				"github.com/kcmvp/archunit/internal.Field",
+				"github.com/kcmvp/archunit/internal.Constant",
+				"github.com/kcmvp/archunit/internal.importerFunc",
+				"github.com/kcmvp/archunit/internal.IndexPackage",
+				"github.com/kcmvp/archunit/internal.Index",
+				"github.com/kcmvp/archunit/internal.CallSite",
 				"github.com/kcmvp/archunit/internal.Function",
 				"github.com/kcmvp/archunit/internal.Package",
 				"github.com/kcmvp/archunit/internal.Param",
@@ -180,6 +278,12 @@ func TestTypes_InPackages(t *testing.T) {
 			pkgs: []string{"kcmvp/archunit/internal"},
 			typs: []string{
 				"github.com/kcmvp/archunit/internal.Artifact",
+				"github.com/kcmvp/archunit/internal.Field",
+				"github.com/kcmvp/archunit/internal.Constant",
+				"github.com/kcmvp/archunit/internal.importerFunc",
+				"github.com/kcmvp/archunit/internal.IndexPackage",
+				"github.com/kcmvp/archunit/internal.Index",
+				"github.com/kcmvp/archunit/internal.CallSite",
 				"github.com/kcmvp/archunit/internal.Function",
 				"github.com/kcmvp/archunit/internal.Package",
 				"github.com/kcmvp/archunit/internal.Param",
@@ -193,6 +297,12 @@ func TestTypes_InPackages(t *testing.T) {
 			pkgs: []string{"archunit/internal", "internal/sample/controller"},
 			typs: []string{
 				"github.com/kcmvp/archunit/internal.Artifact",
+				"github.com/kcmvp/archunit/internal.Field",
+				"github.com/kcmvp/archunit/internal.Constant",
+				"github.com/kcmvp/archunit/internal.importerFunc",
+				"github.com/kcmvp/archunit/internal.IndexPackage",
+				"github.com/kcmvp/archunit/internal.Index",
+				"github.com/kcmvp/archunit/internal.CallSite",
 				"github.com/kcmvp/archunit/internal.Function",
 				"github.com/kcmvp/archunit/internal.Package",
 				"github.com/kcmvp/archunit/internal.Param",
@@ -214,3 +324,111 @@ func TestTypes_InPackages(t *testing.T) {
 		})
 	}
 }
+
+func TestTypes_ShouldOnlyBeConstructedIn(t *testing.T) {
+	arch, err := Load("testdata/bound")
+	assert.NoError(t, err)
+	model, _ := arch.Layer("bound/model")
+	service, _ := arch.Layer("bound/service")
+	api, _ := arch.Layer("bound/api")
+	assert.NoError(t, model.Types().ShouldOnlyBeConstructedIn(service))
+	err = model.Types().ShouldOnlyBeConstructedIn(api)
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Len(t, violations, 1)
+	assert.True(t, strings.HasPrefix(violations[0], "example.com/bound/model.User at "))
+	assert.True(t, strings.HasSuffix(violations[0], "service/service.go:31:9"))
+	assert.NoError(t, api.Types().ShouldOnlyBeConstructedIn())
+}
+
+func TestTypes_ShouldNotBeTypeAsserted(t *testing.T) {
+	service, _ := Layer("sample/service")
+	assert.NoError(t, service.Types().ShouldNotBeTypeAsserted("sample/controller"))
+	err := service.Types().ShouldNotBeTypeAsserted("sample/service")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "github.com/kcmvp/archunit/internal/sample/service.NameService at ")
+	assert.Contains(t, err.Error(), "sample/controller/login_controller.go:")
+	model, _ := Layer("sample/model")
+	assert.NoError(t, model.Types().ShouldNotBeTypeAsserted())
+	assert.Error(t, service.Types().ShouldNotBeTypeAsserted("sample/../service"))
+}
+
+func TestTypes_Hierarchy(t *testing.T) {
+	typeNames := func(typs Types) []string {
+		return lo.Map(typs, func(typ internal.Type, _ int) string {
+			return typ.Name()
+		})
+	}
+	selection := func(names ...string) Types {
+		return lo.Filter(AppTypes(), func(typ internal.Type, _ int) bool {
+			return lo.Contains(names, typ.Name())
+		})
+	}
+	nameService := selection("github.com/kcmvp/archunit/internal/sample/service.NameService")
+	assert.ElementsMatch(t, []string{
+		"github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl",
+		"github.com/kcmvp/archunit/internal/sample/service.FullNameImpl",
+	}, typeNames(nameService.Implementers()))
+	assert.Equal(t, []string{"github.com/kcmvp/archunit/internal/sample/controller.LoginController"}, typeNames(nameService.Users()))
+	assert.NoError(t, nameService.Users().ShouldBeInPackages("github.com/kcmvp/archunit/internal/sample/controller"))
+	assert.Empty(t, nameService.Embedders())
+	loginService := selection("github.com/kcmvp/archunit/internal/sample/service/ext/v1.LoginService")
+	assert.ElementsMatch(t, []string{
+		"github.com/kcmvp/archunit/internal/sample/controller/module1.AppController",
+		"github.com/kcmvp/archunit/internal/sample/service/ext.Cross",
+	}, typeNames(loginService.Embedders()))
+	assert.Empty(t, loginService.Implementers())
+	repository := selection("github.com/kcmvp/archunit/internal/sample/repository.UserRepository")
+	assert.ElementsMatch(t, []string{
+		"github.com/kcmvp/archunit/internal/sample/controller/module1.AppController",
+		"github.com/kcmvp/archunit/internal/sample/service.UserService",
+		"github.com/kcmvp/archunit/internal/sample/service/thirdparty.S3",
+	}, typeNames(repository.Users()))
+	assert.Error(t, repository.Users().ShouldBeInPackages("github.com/kcmvp/archunit/internal/sample/service"))
+	assert.ElementsMatch(t, []string{
+		"github.com/kcmvp/archunit/internal/sample/repository.UserRepository",
+		"github.com/kcmvp/archunit/internal/sample/service.UserService",
+	}, typeNames(selection("github.com/kcmvp/archunit/internal/sample/model.User").Users()))
+}
+
+func TestTypesIntendedToImplement(t *testing.T) {
+	assert.NoError(t, TypesIntendedToImplement("internal/sample/service.NameService", TypeNameMatches(HaveSuffix, "Impl")))
+	err := TypesIntendedToImplement("internal/sample/service.NameService", TypeInPackages("sample/service"))
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{
+		"github.com/kcmvp/archunit/internal/sample/service.Audit misses FirstNameI",
+		"github.com/kcmvp/archunit/internal/sample/service.UserService misses FirstNameI",
+	}, violations)
+	assert.EqualError(t, TypesIntendedToImplement("internal/sample/service.UserService", TypeNameMatches(HaveSuffix, "Impl")),
+		"can not find interface internal/sample/service.UserService")
+}
+
+func TestTypes_ImplementationsShouldBeRegisteredIn(t *testing.T) {
+	arch, err := Load("testdata/plugins")
+	assert.NoError(t, err)
+	pkgs, _ := arch.Packages("plugins/plugin")
+	err = pkgs.Types().ImplementationsShouldBeRegisteredIn("plugin.Register")
+	assert.EqualError(t, err, "implementations are not registered in plugin.Register: [example.com/plugins/impl.Lower]")
+	err = pkgs.Types().ImplementationsShouldBeRegisteredIn("plugin.Unregister")
+	assert.EqualError(t, err, "implementations are not registered in plugin.Unregister: [example.com/plugins/impl.Echo example.com/plugins/impl.Lower example.com/plugins/impl.Upper]")
+	impl, _ := arch.Packages("plugins/impl")
+	assert.NoError(t, impl.Types().ImplementationsShouldBeRegisteredIn("plugin.Unregister"))
+}
+
+func TestMethodsImplementing(t *testing.T) {
+	methods, err := MethodsImplementing("internal/sample/service.NameService")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"(github.com/kcmvp/archunit/internal/sample/service.FullNameImpl).FirstNameI",
+		"(github.com/kcmvp/archunit/internal/sample/service.FullNameImpl).LastNameI",
+		"(github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl).FirstNameI",
+		"(github.com/kcmvp/archunit/internal/sample/service.NameServiceImpl).LastNameI",
+	}, lo.Map(methods, func(f internal.Function, _ int) string {
+		return f.FullName()
+	}))
+	assert.NoError(t, methods.NameShould(HaveSuffix, "NameI"))
+	assert.NoError(t, ErrorShouldBeLastReturn(methods))
+	_, err = MethodsImplementing("internal/sample/service.UserService")
+	assert.EqualError(t, err, "can not find interface internal/sample/service.UserService")
+}
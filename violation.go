@@ -0,0 +1,123 @@
+package archunit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Violation is a violation of a rule reported by Architecture.ValidateWithReport. the file, line and column are the
+// source position of the violating object found by the rule, see Findings, and the object is the package level
+// function, type or variable whose declaration encloses the position, eg: the function of a forbidden call. the owners
+// are attributed by the ownership of the report options, see AttributeOwners
+type Violation struct {
+	RuleID   string   `json:"ruleId"`
+	Category string   `json:"category,omitempty"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Object   string   `json:"object,omitempty"`
+	Owners   []string `json:"owners,omitempty"`
+}
+
+// Findings is the violations found by a rule along with the source positions of the violating objects, eg: the call
+// of a forbidden function. rules wrap Findings rather than Violations so the reports locate the violations without
+// parsing the messages, Findings unwraps to the Violations of the messages for the consumers of the messages.
+// rules wrap the sorted Findings so the reports are stable, see sorted
+type Findings []Violation
+
+func (findings Findings) Error() string {
+	return findings.messages().Error()
+}
+
+func (findings Findings) Unwrap() error {
+	return findings.messages()
+}
+
+func (findings Findings) messages() Violations {
+	return lo.Map(findings, func(violation Violation, _ int) string {
+		return violation.Message
+	})
+}
+
+// sorted returns the findings sorted by the messages
+func (findings Findings) sorted() Findings {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Message < findings[j].Message
+	})
+	return findings
+}
+
+// violationAt returns the violation of the message found at the source position
+func violationAt(position token.Position, message string) Violation {
+	return Violation{Message: message, File: position.Filename, Line: position.Line, Column: position.Column}
+}
+
+// found returns the violations wrapped in the error of a rule, the findings with their positions or the violations,
+// and true. the message of the error is the only violation when the error wraps none
+func found(err error) ([]Violation, bool) {
+	var findings Findings
+	if errors.As(err, &findings) {
+		return append([]Violation{}, findings...), true
+	}
+	var violations Violations
+	if errors.As(err, &violations) {
+		return lo.Map(violations, func(message string, _ int) Violation {
+			return Violation{Message: message}
+		}), true
+	}
+	return []Violation{{Message: err.Error()}}, false
+}
+
+// rewrap replaces the violations wrapped in the error of a rule with the violations, the rest of the error is kept
+func rewrap(err error, violations []Violation) error {
+	wrapped, _ := found(err)
+	prefix, suffix, _ := strings.Cut(err.Error(), Findings(wrapped).Error())
+	return fmt.Errorf("%s%w%s", prefix, Findings(violations), suffix)
+}
+
+// violation returns the violation of the rule with the enclosing object and the owners of its position
+func (arch Architecture) violation(rule Rule, violation Violation) Violation {
+	violation.RuleID, violation.Category = rule.name, rule.category
+	if violation.File != "" {
+		if obj, ok := arch.artifact.Enclosing(violation.File, violation.Line); ok {
+			violation.Object = qualifiedName(obj)
+		}
+	}
+	violation.Owners = arch.owners(violation)
+	return violation
+}
+
+// ViolationContext is the violation passed to the message format of the rule, see Rule.WithMessage
+type ViolationContext struct {
+	Violation
+	Rationale string
+}
+
+// trimSummary matches the summaries of the trimmed violations added by the report options
+var trimSummary = regexp.MustCompile(`^\.\.\. and \d+ more$`)
+
+// format replaces the messages of the violations and the error of the rule with the messages of its format, the
+// positions of the violations are kept as they were found
+func (rule Rule) format(err error, violations []Violation, wrapped bool) error {
+	rule.formatMessages(violations)
+	if !wrapped {
+		return errors.New(violations[0].Message)
+	}
+	return rewrap(err, violations)
+}
+
+// formatMessages replaces the messages of the violations with the messages of the format of the rule, the summaries
+// of the trimmed violations are kept
+func (rule Rule) formatMessages(violations []Violation) {
+	lo.ForEach(violations, func(violation Violation, i int) {
+		if !trimSummary.MatchString(violation.Message) {
+			violations[i].Message = rule.message(ViolationContext{Violation: violation, Rationale: rule.rationale})
+		}
+	})
+}
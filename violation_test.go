@@ -0,0 +1,107 @@
+package archunit
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type recordReporter struct {
+	results []RuleResult
+}
+
+func (reporter *recordReporter) Report(_ Architecture, results []RuleResult) error {
+	reporter.results = results
+	return nil
+}
+
+func TestArchitecture_ValidateWithReport(t *testing.T) {
+	ordering := NewRule("ordering", func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+	}, "sample/...").WithCategory("package")
+	naming := NewRule("naming", func(pkgs ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views")
+	reporter := &recordReporter{}
+	err := Project().ValidateWithReport(reporter, ordering, naming)
+	assert.Equal(t, Project().Validate(ordering, naming).Error(), err.Error())
+	assert.Len(t, reporter.results, 2)
+	violation := reporter.results[0].Violations[0]
+	assert.Equal(t, Violation{
+		RuleID:   "ordering",
+		Category: "package",
+		Message:  violation.Message,
+		File:     filepath.Join(Project().RootDir(), "internal/sample/flags/flags.go"),
+		Line:     27,
+		Column:   4,
+		Object:   "github.com/kcmvp/archunit/internal/sample/flags.Names",
+	}, violation)
+	assert.Equal(t, "naming", reporter.results[1].Violations[0].RuleID)
+	assert.Empty(t, reporter.results[1].Violations[0].File)
+	assert.Empty(t, reporter.results[1].Violations[0].Object)
+}
+
+func TestRule_WithMessage(t *testing.T) {
+	format := func(v ViolationContext) string {
+		return fmt.Sprintf("%s in %s, because %s, see https://wiki.example.com/%s", v.Object, filepath.Base(v.File), v.Rationale, v.RuleID)
+	}
+	ordering := NewRule("ordering", func(pkgs ArchPackage) error {
+		return pkgs.ShouldNotRangeOverMapWhenBuildingOrderedOutput()
+	}, "sample/...").Because("map order is random").WithMessage(format)
+	reporter := &recordReporter{}
+	err := Project().ValidateWithReport(reporter, ordering)
+	violations := reporter.results[0].Violations
+	assert.Equal(t, "github.com/kcmvp/archunit/internal/sample/flags.Names in flags.go, because map order is random, see https://wiki.example.com/ordering", violations[0].Message)
+	assert.Equal(t, 27, violations[0].Line)
+	assert.True(t, strings.HasPrefix(err.Error(), "ordering: "))
+	assert.Contains(t, err.Error(), "[github.com/kcmvp/archunit/internal/sample/flags.Names in flags.go, because map order is random")
+	naming := NewRule("naming", func(pkgs ArchPackage) error {
+		return pkgs.NameShouldBeSameAsFolder()
+	}, "sample/views").WithMessage(func(v ViolationContext) string {
+		return "owned by the views team: " + v.Message
+	})
+	assert.EqualError(t, Project().Validate(naming), "naming: owned by the views team: package name and folder not the same: [github.com/kcmvp/archunit/internal/sample/views]")
+	many := NewRule("many", func(_ ArchPackage) error {
+		return fmt.Errorf("found %w", Violations{"a", "b", "c"})
+	}).WithMessage(func(v ViolationContext) string {
+		return strings.ToUpper(v.Message)
+	})
+	assert.EqualError(t, Project().With(MaxViolationsPerRule(1)).Validate(many), "many: found [A ... and 2 more]")
+}
+
+func TestFindings(t *testing.T) {
+	findings := Findings{{Message: "b.go:2:1 b", File: "b.go", Line: 2, Column: 1}, {Message: "a"}}
+	err := fmt.Errorf("found: %w", findings.sorted())
+	assert.EqualError(t, err, "found: [a b.go:2:1 b]")
+	var violations Violations
+	assert.ErrorAs(t, err, &violations)
+	assert.Equal(t, Violations{"a", "b.go:2:1 b"}, violations)
+	result, wrapped := found(err)
+	assert.True(t, wrapped)
+	assert.Equal(t, []Violation(findings), result)
+	// positions are never parsed out of the messages
+	result, wrapped = found(fmt.Errorf("found: %w", Violations{"b.go:2:1 b"}))
+	assert.True(t, wrapped)
+	assert.Equal(t, []Violation{{Message: "b.go:2:1 b"}}, result)
+	assert.EqualError(t, rewrap(err, []Violation{{Message: "c"}}), "found: [c]")
+}
+
+func TestArchitecture_ValidateWithReport_Positions(t *testing.T) {
+	arch, err := Load("testdata/bound")
+	assert.NoError(t, err)
+	params := NewRule("params", func(pkgs ArchPackage) error {
+		return pkgs.Functions().ShouldHaveAtMostParams(0)
+	}, "api")
+	reporter := &recordReporter{}
+	assert.Error(t, arch.ValidateWithReport(reporter, params))
+	violation, ok := lo.Find(reporter.results[0].Violations, func(violation Violation) bool {
+		return violation.Object == "example.com/bound/api.Open"
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "example.com/bound/api.Open (1)", violation.Message)
+	assert.Equal(t, filepath.Join(arch.RootDir(), "api", "api.go"), violation.File)
+	assert.Equal(t, 72, violation.Line)
+}